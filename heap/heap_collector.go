@@ -0,0 +1,35 @@
+package heap
+
+import (
+	"cmp"
+
+	"github.com/wesleylin/basin/stream"
+)
+
+// Collector gathers stream elements into a Min-Heap using priorityFn.
+// Use it with stream.CollectWith.
+func Collector[P cmp.Ordered, T any](priorityFn func(T) P) stream.Collector[T, *Heap[P, T], *Heap[P, T]] {
+	return collector(New[P, T], priorityFn)
+}
+
+// MaxCollector gathers stream elements into a Max-Heap using priorityFn.
+func MaxCollector[P cmp.Ordered, T any](priorityFn func(T) P) stream.Collector[T, *Heap[P, T], *Heap[P, T]] {
+	return collector(NewMax[P, T], priorityFn)
+}
+
+func collector[P cmp.Ordered, T any](supplier func() *Heap[P, T], priorityFn func(T) P) stream.Collector[T, *Heap[P, T], *Heap[P, T]] {
+	return stream.Collector[T, *Heap[P, T], *Heap[P, T]]{
+		Supplier: supplier,
+		Accumulator: func(h *Heap[P, T], v T) *Heap[P, T] {
+			h.Insert(priorityFn(v), v)
+			return h
+		},
+		Combiner: func(a, b *Heap[P, T]) *Heap[P, T] {
+			for _, e := range b.data {
+				a.Insert(e.priority, e.value)
+			}
+			return a
+		},
+		Finisher: func(h *Heap[P, T]) *Heap[P, T] { return h },
+	}
+}
@@ -3,6 +3,8 @@ package heap
 import (
 	"slices"
 	"testing"
+
+	"github.com/wesleylin/basin/stream"
 )
 
 func TestHeapBasic(t *testing.T) {
@@ -148,3 +150,15 @@ func TestHeapMemorySafety(t *testing.T) {
 		t.Error("Pop did not zero out the underlying array element; potential memory leak")
 	}
 }
+
+func TestHeapCollector(t *testing.T) {
+	h, err := stream.CollectWith(stream.FromSlice([]string{"ccc", "a", "bb"}), MaxCollector(func(s string) int {
+		return len(s)
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := slices.Collect(h.Drain()); !slices.Equal(got, []string{"ccc", "bb", "a"}) {
+		t.Errorf("expected [ccc bb a], got %v", got)
+	}
+}
@@ -1,10 +1,15 @@
 package hash
 
 import (
+	"hash/maphash"
 	"unsafe"
 )
 
+// seed is shared by every Maphash call, so equal keys hash alike process-wide.
+var seed = maphash.MakeSeed()
+
 // Maphash returns a high-speed hash for any comparable key.
+// Equal keys always hash alike within a process.
 func Maphash[K comparable](key K) uint64 {
 	// We use the 'any' trick to detect the underlying type
 	var i any = key
@@ -14,16 +19,16 @@ func Maphash[K comparable](key K) uint64 {
 		// For strings, we MUST hash the actual bytes, not the header.
 		// unsafe.StringData is the safe, modern way (Go 1.20+) to get the pointer.
 		return uint64(memhash(unsafe.Pointer(unsafe.StringData(v)), 0, uintptr(len(v))))
-	case int, int64, uint64, float64:
+	case int, int64, uint64:
 		// Fixed size 8-byte types
 		return uint64(memhash(unsafe.Pointer(&key), 0, 8))
-	case int32, uint32, float32:
+	case int32, uint32:
 		// Fixed size 4-byte types
 		return uint64(memhash(unsafe.Pointer(&key), 0, 4))
 	default:
-		// For structs and other types, we hash the raw memory of the variable.
-		// This works for any comparable struct.
-		return uint64(memhash(unsafe.Pointer(&key), 0, unsafe.Sizeof(key)))
+		// Raw memory is wrong for anything holding a string or pointer, and
+		// for floats (+0 == -0), so let the runtime hash by value, as maps do.
+		return maphash.Comparable(seed, key)
 	}
 }
 
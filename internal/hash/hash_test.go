@@ -1,6 +1,7 @@
 package hash
 
 import (
+	"math"
 	"testing"
 )
 
@@ -35,6 +36,18 @@ func TestMaphash(t *testing.T) {
 		if Maphash(e1) != Maphash(e2) {
 			t.Error("identical structs should produce identical hashes")
 		}
+
+		// equal strings in separate allocations must not hash by address
+		e3 := entity{ID: 10, Name: string([]byte("Basin"))}
+		if Maphash(e1) != Maphash(e3) {
+			t.Error("equal structs with separately allocated strings should produce identical hashes")
+		}
+	})
+
+	t.Run("SignedZero", func(t *testing.T) {
+		if Maphash(float64(0)) != Maphash(negZero()) {
+			t.Error("+0 and -0 are equal and should produce identical hashes")
+		}
 	})
 }
 
@@ -45,3 +58,7 @@ func BenchmarkMaphash(b *testing.B) {
 		_ = Maphash(key)
 	}
 }
+
+func negZero() float64 {
+	return math.Copysign(0, -1)
+}
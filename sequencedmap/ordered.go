@@ -163,8 +163,6 @@ func (m *Map[K, V]) Values() iter.Seq[V] {
 
 // Stream returns a Basin Stream2 which yields keys and values in insertion order.
 func (m *Map[K, V]) Stream2() stream.Stream2[K, V] {
-	var err error
-
 	// Create the iterator logic
 	seq := func(yield func(K, V) bool) {
 		// Replace this loop with however your map actually iterates.
@@ -176,7 +174,7 @@ func (m *Map[K, V]) Stream2() stream.Stream2[K, V] {
 		}
 	}
 
-	return stream.New2(seq, &err)
+	return stream.FromSeq2(seq)
 }
 
 // Convenience methods
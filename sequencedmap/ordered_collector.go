@@ -0,0 +1,23 @@
+package sequencedmap
+
+import "github.com/wesleylin/basin/stream"
+
+// Collector gathers stream elements into a Map in first-seen key order.
+// A repeated key overwrites the value but keeps its original position.
+// Use it with stream.CollectWith.
+func Collector[T any, K comparable, V any](keyFn func(T) K, valFn func(T) V) stream.Collector[T, *Map[K, V], *Map[K, V]] {
+	return stream.Collector[T, *Map[K, V], *Map[K, V]]{
+		Supplier: New[K, V],
+		Accumulator: func(m *Map[K, V], v T) *Map[K, V] {
+			m.Put(keyFn(v), valFn(v))
+			return m
+		},
+		Combiner: func(a, b *Map[K, V]) *Map[K, V] {
+			for k, v := range b.All() {
+				a.Put(k, v)
+			}
+			return a
+		},
+		Finisher: func(m *Map[K, V]) *Map[K, V] { return m },
+	}
+}
@@ -0,0 +1,10 @@
+package sequencedmap
+
+import "github.com/wesleylin/basin/stream"
+
+// Diff compares two snapshots of a map and yields every key that changed
+// between from and to, including keys whose position changed (stream.Moved).
+// eq compares values; nil uses reflect.DeepEqual. See stream.DiffSequenced.
+func Diff[K comparable, V any](from, to *Map[K, V], eq func(a, b V) bool) stream.Stream2[K, stream.Change[V]] {
+	return stream.DiffSequenced(from.All(), to.All(), eq)
+}
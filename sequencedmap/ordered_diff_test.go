@@ -0,0 +1,49 @@
+package sequencedmap_test
+
+import (
+	"testing"
+
+	orderedmap "github.com/wesleylin/basin/sequencedmap"
+	"github.com/wesleylin/basin/stream"
+)
+
+func TestDiff(t *testing.T) {
+	v1 := orderedmap.New[string, string]()
+	v1.Put("host", "localhost")
+	v1.Put("port", "8080")
+	v1.Put("debug", "false")
+
+	v2 := orderedmap.New[string, string]()
+	v2.Put("port", "9090")
+	v2.Put("host", "localhost")
+	v2.Put("tls", "on")
+
+	kinds := make(map[string][]stream.ChangeKind)
+	err := orderedmap.Diff(v1, v2, nil).ForEach(func(k string, c stream.Change[string]) {
+		kinds[k] = append(kinds[k], c.Kind)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string][]stream.ChangeKind{
+		"debug": {stream.Removed},
+		"port":  {stream.Updated, stream.Moved},
+		"tls":   {stream.Added},
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("got %v, want %v", kinds, want)
+	}
+	for k, w := range want {
+		got := kinds[k]
+		if len(got) != len(w) {
+			t.Errorf("%s: got %v, want %v", k, got, w)
+			continue
+		}
+		for i := range w {
+			if got[i] != w[i] {
+				t.Errorf("%s: got %v, want %v", k, got, w)
+			}
+		}
+	}
+}
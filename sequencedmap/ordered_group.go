@@ -0,0 +1,25 @@
+package sequencedmap
+
+import "github.com/wesleylin/basin/stream"
+
+// GroupByOrdered organizes stream elements into slices keyed by keyFn.
+// Unlike stream.GroupBy, groups are kept in first-seen key order.
+func GroupByOrdered[T any, K comparable](s stream.Stream[T], keyFn func(T) K) (*Map[K, []T], error) {
+	return Aggregate(s, keyFn, func() []T { return nil }, func(group []T, v T) []T {
+		return append(group, v)
+	})
+}
+
+// Aggregate folds each group of elements sharing a key into one accumulator,
+// returned in first-seen key order. Each group starts from a fresh init().
+// Only the accumulators are kept in memory.
+func Aggregate[T any, K comparable, A any](s stream.Stream[T], keyFn func(T) K, init func() A, fold func(A, T) A) (*Map[K, A], error) {
+	m := New[K, A]()
+	err := stream.Aggregate(s, keyFn, init, fold).ForEach(func(k K, acc A) {
+		m.Put(k, acc)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
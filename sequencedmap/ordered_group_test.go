@@ -0,0 +1,89 @@
+package sequencedmap_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	orderedmap "github.com/wesleylin/basin/sequencedmap"
+	"github.com/wesleylin/basin/stream"
+)
+
+type order struct {
+	Customer string
+	Amount   int
+}
+
+var orders = []order{
+	{"zed", 10},
+	{"amy", 5},
+	{"zed", 7},
+	{"bob", 1},
+	{"amy", 3},
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	groups, err := orderedmap.GroupByOrdered(stream.FromSlice(orders), func(o order) string {
+		return o.Customer
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// groups appear in first-seen order, not sorted or random
+	if got := slices.Collect(groups.Keys()); !slices.Equal(got, []string{"zed", "amy", "bob"}) {
+		t.Errorf("expected [zed amy bob], got %v", got)
+	}
+	if zed, _ := groups.Get("zed"); len(zed) != 2 || zed[1].Amount != 7 {
+		t.Errorf("unexpected zed group: %v", zed)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	t.Run("Sum per key", func(t *testing.T) {
+		totals, err := orderedmap.Aggregate(stream.FromSlice(orders), func(o order) string {
+			return o.Customer
+		}, func() int { return 0 }, func(sum int, o order) int {
+			return sum + o.Amount
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got []int
+		for _, v := range totals.All() {
+			got = append(got, v)
+		}
+		if !slices.Equal(got, []int{17, 8, 1}) {
+			t.Errorf("expected [17 8 1], got %v", got)
+		}
+	})
+
+	t.Run("Fresh accumulator per group", func(t *testing.T) {
+		// a map accumulator must not be shared between groups
+		seen, err := orderedmap.Aggregate(stream.FromSlice(orders), func(o order) string {
+			return o.Customer
+		}, func() map[int]bool { return map[int]bool{} }, func(m map[int]bool, o order) map[int]bool {
+			m[o.Amount] = true
+			return m
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if bob, _ := seen.Get("bob"); len(bob) != 1 || !bob[1] {
+			t.Errorf("expected bob to have seen only 1, got %v", bob)
+		}
+	})
+
+	t.Run("Error Propagation", func(t *testing.T) {
+		errSource := errors.New("read failure")
+		s := stream.New(func(yield func(order) bool) {
+			yield(order{"amy", 1})
+		}, &errSource)
+
+		if _, err := orderedmap.GroupByOrdered(s, func(o order) string { return o.Customer }); err != errSource {
+			t.Errorf("expected %v, got %v", errSource, err)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	orderedmap "github.com/wesleylin/basin/sequencedmap"
+	"github.com/wesleylin/basin/stream"
 )
 
 func TestMapBasic(t *testing.T) {
@@ -333,3 +334,27 @@ func TestMapOrderStability(t *testing.T) {
 		}
 	}
 }
+
+func TestCollector(t *testing.T) {
+	type Animal struct {
+		ID   string
+		Name string
+	}
+	zoo := []Animal{{"A02", "Leo"}, {"A01", "Tony"}, {"A02", "Leo II"}}
+
+	m, err := stream.CollectWith(stream.FromSlice(zoo), orderedmap.Collector(
+		func(a Animal) string { return a.ID },
+		func(a Animal) string { return a.Name },
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// first-seen order, last value wins
+	if got := slices.Collect(m.Keys()); !slices.Equal(got, []string{"A02", "A01"}) {
+		t.Errorf("expected [A02 A01], got %v", got)
+	}
+	if v, _ := m.Get("A02"); v != "Leo II" {
+		t.Errorf("expected Leo II, got %s", v)
+	}
+}
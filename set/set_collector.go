@@ -0,0 +1,22 @@
+package set
+
+import "github.com/wesleylin/basin/stream"
+
+// Collector gathers stream elements into a Set, keeping first-seen order.
+// Use it with stream.CollectWith.
+func Collector[K comparable]() stream.Collector[K, *Set[K], *Set[K]] {
+	return stream.Collector[K, *Set[K], *Set[K]]{
+		Supplier: New[K],
+		Accumulator: func(s *Set[K], v K) *Set[K] {
+			s.Insert(v)
+			return s
+		},
+		Combiner: func(a, b *Set[K]) *Set[K] {
+			for v := range b.All() {
+				a.Insert(v)
+			}
+			return a
+		},
+		Finisher: func(s *Set[K]) *Set[K] { return s },
+	}
+}
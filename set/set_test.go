@@ -2,7 +2,10 @@ package set
 
 import (
 	"fmt"
+	"slices"
 	"testing"
+
+	"github.com/wesleylin/basin/stream"
 )
 
 func TestSetBasic(t *testing.T) {
@@ -171,3 +174,13 @@ func TestDifference(t *testing.T) {
 		t.Errorf("Expected %d items, got %d", len(expected), count)
 	}
 }
+
+func TestSetCollector(t *testing.T) {
+	s, err := stream.CollectWith(stream.FromSlice([]string{"b", "a", "b", "c"}), Collector[string]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.ToSlice(); !slices.Equal(got, []string{"b", "a", "c"}) {
+		t.Errorf("expected [b a c], got %v", got)
+	}
+}
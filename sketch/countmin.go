@@ -0,0 +1,128 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"github.com/wesleylin/basin/internal/hash"
+)
+
+// ErrIncompatible is returned by Merge when two sketches were built with different dimensions.
+var ErrIncompatible = errors.New("sketch: incompatible dimensions")
+
+// CountMin is a Count-Min Sketch: a fixed-size frequency estimator for unbounded key sets.
+//
+// Error bounds: with width w = ceil(e/epsilon) and depth d = ceil(ln(1/delta)),
+// for a stream of N total increments every estimate satisfies
+//
+//	true(k) <= Estimate(k)                        (always)
+//	Estimate(k) <= true(k) + epsilon*N            (with probability >= 1-delta)
+//
+// All methods are safe for concurrent use. Counters are updated with atomics,
+// so Add never blocks.
+type CountMin[K comparable] struct {
+	width  uint64
+	depth  uint64
+	counts []uint64 // depth rows of width counters, row-major
+	total  uint64
+}
+
+// NewCountMin returns a sketch sized for the given error rate (epsilon) and
+// failure probability (delta). Both must be in the open interval (0, 1).
+func NewCountMin[K comparable](epsilon, delta float64) *CountMin[K] {
+	if epsilon <= 0 || epsilon >= 1 {
+		panic(fmt.Sprintf("sketch: epsilon must be in (0, 1), got %v", epsilon))
+	}
+	if delta <= 0 || delta >= 1 {
+		panic(fmt.Sprintf("sketch: delta must be in (0, 1), got %v", delta))
+	}
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinWithSize[K](width, depth)
+}
+
+// NewCountMinWithSize returns a sketch with an explicit number of columns and rows.
+func NewCountMinWithSize[K comparable](width, depth int) *CountMin[K] {
+	if width < 1 || depth < 1 {
+		panic(fmt.Sprintf("sketch: width and depth must be positive, got %d x %d", width, depth))
+	}
+	return &CountMin[K]{
+		width:  uint64(width),
+		depth:  uint64(depth),
+		counts: make([]uint64, width*depth),
+	}
+}
+
+// Add records one occurrence of key.
+func (cm *CountMin[K]) Add(key K) {
+	cm.AddN(key, 1)
+}
+
+// AddN records n occurrences of key.
+func (cm *CountMin[K]) AddN(key K, n uint64) {
+	h1, h2 := splitHash(hash.Maphash(key))
+	for i := uint64(0); i < cm.depth; i++ {
+		atomic.AddUint64(&cm.counts[cm.cell(i, h1, h2)], n)
+	}
+	atomic.AddUint64(&cm.total, n)
+}
+
+// Estimate returns the estimated number of occurrences of key.
+// It never underestimates.
+func (cm *CountMin[K]) Estimate(key K) uint64 {
+	h1, h2 := splitHash(hash.Maphash(key))
+	est := uint64(math.MaxUint64)
+	for i := uint64(0); i < cm.depth; i++ {
+		est = min(est, atomic.LoadUint64(&cm.counts[cm.cell(i, h1, h2)]))
+	}
+	return est
+}
+
+// Total returns the number of occurrences recorded so far (N).
+func (cm *CountMin[K]) Total() uint64 {
+	return atomic.LoadUint64(&cm.total)
+}
+
+// Width returns the number of counters per row.
+func (cm *CountMin[K]) Width() int { return int(cm.width) }
+
+// Depth returns the number of rows (independent hash functions).
+func (cm *CountMin[K]) Depth() int { return int(cm.depth) }
+
+// Merge adds the counts of other into cm. Both sketches must have the same
+// dimensions. Since keys are hashed with a per-process seed, only sketches
+// built in the same process can be merged.
+func (cm *CountMin[K]) Merge(other *CountMin[K]) error {
+	if cm.width != other.width || cm.depth != other.depth {
+		return fmt.Errorf("%w: %dx%d vs %dx%d", ErrIncompatible, cm.width, cm.depth, other.width, other.depth)
+	}
+	for i := range other.counts {
+		atomic.AddUint64(&cm.counts[i], atomic.LoadUint64(&other.counts[i]))
+	}
+	atomic.AddUint64(&cm.total, atomic.LoadUint64(&other.total))
+	return nil
+}
+
+// Reset zeroes every counter.
+func (cm *CountMin[K]) Reset() {
+	for i := range cm.counts {
+		atomic.StoreUint64(&cm.counts[i], 0)
+	}
+	atomic.StoreUint64(&cm.total, 0)
+}
+
+// cell returns the index of the counter for row i.
+// Rows use Kirsch-Mitzenmacher double hashing: g_i(x) = h1(x) + i*h2(x).
+func (cm *CountMin[K]) cell(i, h1, h2 uint64) uint64 {
+	return i*cm.width + (h1+i*h2)%cm.width
+}
+
+// splitHash derives two hashes from a single 64-bit hash.
+func splitHash(h uint64) (uint64, uint64) {
+	h1 := h & 0xffffffff
+	// Force h2 odd so it is never zero and the rows do not all share one column.
+	h2 := (h >> 32) | 1
+	return h1, h2
+}
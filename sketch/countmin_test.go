@@ -0,0 +1,140 @@
+package sketch
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/wesleylin/basin/stream"
+)
+
+// zipfKeys returns a skewed, reproducible workload and its exact counts.
+func zipfKeys(n int) ([]uint64, map[uint64]uint64) {
+	r := rand.New(rand.NewSource(42))
+	z := rand.NewZipf(r, 1.2, 1, 10000)
+	keys := make([]uint64, n)
+	truth := make(map[uint64]uint64)
+	for i := range keys {
+		keys[i] = z.Uint64()
+		truth[keys[i]]++
+	}
+	return keys, truth
+}
+
+func TestCountMin_ErrorBounds(t *testing.T) {
+	const epsilon, delta = 0.001, 0.01
+	keys, truth := zipfKeys(200000)
+
+	cm := NewCountMin[uint64](epsilon, delta)
+	for _, k := range keys {
+		cm.Add(k)
+	}
+
+	if cm.Total() != uint64(len(keys)) {
+		t.Fatalf("expected total %d, got %d", len(keys), cm.Total())
+	}
+
+	bound := uint64(epsilon * float64(cm.Total()))
+	violations := 0
+	for k, want := range truth {
+		got := cm.Estimate(k)
+		if got < want {
+			t.Fatalf("underestimate for %d: got %d, want >= %d", k, got, want)
+		}
+		if got-want > bound {
+			violations++
+		}
+	}
+
+	// At most a delta fraction of keys may exceed epsilon*N.
+	if float64(violations) > delta*float64(len(truth)) {
+		t.Errorf("%d of %d keys exceeded the error bound %d", violations, len(truth), bound)
+	}
+}
+
+func TestCountMin_Merge(t *testing.T) {
+	keys, truth := zipfKeys(50000)
+	a := NewCountMin[uint64](0.001, 0.01)
+	b := NewCountMin[uint64](0.001, 0.01)
+	for i, k := range keys {
+		if i%2 == 0 {
+			a.Add(k)
+		} else {
+			b.Add(k)
+		}
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Total() != uint64(len(keys)) {
+		t.Errorf("expected merged total %d, got %d", len(keys), a.Total())
+	}
+	for k, want := range truth {
+		if got := a.Estimate(k); got < want {
+			t.Fatalf("merged underestimate for %d: got %d, want >= %d", k, got, want)
+		}
+	}
+
+	other := NewCountMinWithSize[uint64](10, 2)
+	if err := a.Merge(other); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestCountMin_Concurrency(t *testing.T) {
+	cm := NewCountMin[string](0.01, 0.01)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				cm.Add("hot")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := cm.Estimate("hot"); got < 8000 {
+		t.Errorf("expected at least 8000, got %d", got)
+	}
+}
+
+func TestCountMinOf(t *testing.T) {
+	cm, err := CountMinOf(stream.FromSlice([]string{"a", "b", "a", "c", "a"}), 0.01, 0.01)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cm.Estimate("a"); got < 3 {
+		t.Errorf("expected at least 3, got %d", got)
+	}
+
+	errSource := errors.New("read failure")
+	s := stream.New(func(yield func(string) bool) {
+		yield("a")
+	}, &errSource)
+	if _, err := CountMinOf(s, 0.01, 0.01); err != errSource {
+		t.Errorf("expected %v, got %v", errSource, err)
+	}
+}
+
+func TestCountMin_StructKey(t *testing.T) {
+	type key struct {
+		S string
+		N int
+	}
+	cm := NewCountMin[key](0.01, 0.01)
+	for i := range 5 {
+		// build each string separately so equal keys don't share memory
+		cm.Add(key{S: string([]byte("user")), N: i % 2})
+	}
+
+	if got := cm.Estimate(key{S: "user", N: 0}); got < 3 {
+		t.Errorf("expected at least 3, got %d", got)
+	}
+	if got := cm.Estimate(key{S: "user", N: 1}); got < 2 {
+		t.Errorf("expected at least 2, got %d", got)
+	}
+}
@@ -0,0 +1,34 @@
+package sketch
+
+import "github.com/wesleylin/basin/stream"
+
+// AddStream records every element of s. It returns the stream's error, if any;
+// elements seen before the error are still counted.
+func (cm *CountMin[K]) AddStream(s stream.Stream[K]) error {
+	return s.ForEach(cm.Add)
+}
+
+// AddStream records every element of s. It returns the stream's error, if any;
+// elements seen before the error are still counted.
+func (t *TopK[K]) AddStream(s stream.Stream[K]) error {
+	return s.ForEach(t.Add)
+}
+
+// CountMinOf is a terminal that drains s into a new Count-Min Sketch.
+func CountMinOf[K comparable](s stream.Stream[K], epsilon, delta float64) (*CountMin[K], error) {
+	cm := NewCountMin[K](epsilon, delta)
+	if err := cm.AddStream(s); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// TopKOf is a terminal that returns the k most frequent elements of s,
+// ordered by descending count, using O(k) memory.
+func TopKOf[K comparable](s stream.Stream[K], k int) ([]Counter[K], error) {
+	t := NewTopK[K](k)
+	if err := t.AddStream(s); err != nil {
+		return nil, err
+	}
+	return t.Top(), nil
+}
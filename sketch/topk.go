@@ -0,0 +1,258 @@
+package sketch
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Counter is a tracked key with its estimated count.
+// Error is the maximum amount by which Count may overestimate the true count,
+// so the true count always lies in [Count-Error, Count].
+type Counter[K comparable] struct {
+	Key   K
+	Count uint64
+	Error uint64
+}
+
+// TopK tracks the most frequent keys of a stream using the Space-Saving algorithm.
+//
+// Error bounds: with capacity k and N total increments,
+//
+//	true(x) <= Count(x) <= true(x) + N/k   for every tracked key x
+//	every key with true(x) > N/k is tracked
+//
+// Memory is O(k) regardless of how many distinct keys are seen.
+// All methods are safe for concurrent use.
+type TopK[K comparable] struct {
+	mu    sync.Mutex
+	k     int
+	total uint64
+	index map[K]int    // key -> position in heap
+	heap  []Counter[K] // min-heap ordered by Count
+}
+
+// NewTopK returns a tracker holding at most k counters.
+func NewTopK[K comparable](k int) *TopK[K] {
+	if k < 1 {
+		panic(fmt.Sprintf("sketch: k must be positive, got %d", k))
+	}
+	return &TopK[K]{
+		k:     k,
+		index: make(map[K]int, k),
+		heap:  make([]Counter[K], 0, k),
+	}
+}
+
+// Add records one occurrence of key.
+func (t *TopK[K]) Add(key K) {
+	t.AddN(key, 1)
+}
+
+// AddN records n occurrences of key.
+func (t *TopK[K]) AddN(key K, n uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total += n
+	t.add(key, n)
+}
+
+// add is the Space-Saving update step. Callers must hold t.mu.
+func (t *TopK[K]) add(key K, n uint64) {
+	// 1. Already tracked: bump in place
+	if i, ok := t.index[key]; ok {
+		t.heap[i].Count += n
+		t.down(i)
+		return
+	}
+
+	// 2. Free slot: start a fresh counter
+	if len(t.heap) < t.k {
+		t.heap = append(t.heap, Counter[K]{Key: key, Count: n})
+		t.index[key] = len(t.heap) - 1
+		t.up(len(t.heap) - 1)
+		return
+	}
+
+	// 3. Full: evict the minimum and inherit its count as error
+	evicted := t.heap[0]
+	delete(t.index, evicted.Key)
+	t.heap[0] = Counter[K]{
+		Key:   key,
+		Count: evicted.Count + n,
+		Error: evicted.Count,
+	}
+	t.index[key] = 0
+	t.down(0)
+}
+
+// Estimate returns the counter for key, or false if it is not tracked.
+func (t *TopK[K]) Estimate(key K) (Counter[K], bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	i, ok := t.index[key]
+	if !ok {
+		return Counter[K]{}, false
+	}
+	return t.heap[i], true
+}
+
+// Top returns the tracked counters ordered by descending count.
+// Ties are broken by the smaller error first.
+func (t *TopK[K]) Top() []Counter[K] {
+	t.mu.Lock()
+	res := slices.Clone(t.heap)
+	t.mu.Unlock()
+
+	slices.SortFunc(res, byCount[K])
+	return res
+}
+
+// HeavyHitters returns the counters whose count exceeds phi*N, ordered by
+// descending count. For phi >= 1/k no true heavy hitter is missed; a result
+// with Count-Error <= phi*N may be a false positive.
+func (t *TopK[K]) HeavyHitters(phi float64) []Counter[K] {
+	threshold := phi * float64(t.Total())
+	return slices.DeleteFunc(t.Top(), func(c Counter[K]) bool {
+		return float64(c.Count) <= threshold
+	})
+}
+
+// Total returns the number of occurrences recorded so far (N).
+func (t *TopK[K]) Total() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
+
+// Len returns the number of tracked keys.
+func (t *TopK[K]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.heap)
+}
+
+// Merge folds the counters of other into t. When both trackers share the
+// same capacity k, the merged summary keeps the same guarantees over the
+// combined stream: overestimation stays within (N1+N2)/k.
+func (t *TopK[K]) Merge(other *TopK[K]) {
+	// snapshot other first so we never hold both locks
+	other.mu.Lock()
+	theirs := slices.Clone(other.heap)
+	theirTotal := other.total
+	theirFloor := uint64(0)
+	if len(other.heap) == other.k {
+		theirFloor = other.heap[0].Count
+	}
+	other.mu.Unlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ourFloor := uint64(0)
+	if len(t.heap) == t.k {
+		ourFloor = t.heap[0].Count
+	}
+
+	// A key missing from a full summary may still have occurred up to that
+	// summary's minimum count, so charge the floor to both count and error.
+	merged := make(map[K]Counter[K], len(t.heap)+len(theirs))
+	for _, c := range t.heap {
+		merged[c.Key] = c
+	}
+	shared := make(map[K]bool, len(theirs))
+	for _, c := range theirs {
+		if m, ok := merged[c.Key]; ok {
+			m.Count += c.Count
+			m.Error += c.Error
+			merged[c.Key] = m
+			shared[c.Key] = true
+			continue
+		}
+		c.Count += ourFloor
+		c.Error += ourFloor
+		merged[c.Key] = c
+	}
+	for _, c := range t.heap {
+		if !shared[c.Key] {
+			c.Count += theirFloor
+			c.Error += theirFloor
+			merged[c.Key] = c
+		}
+	}
+
+	all := make([]Counter[K], 0, len(merged))
+	for _, c := range merged {
+		all = append(all, c)
+	}
+	slices.SortFunc(all, byCount[K])
+	if len(all) > t.k {
+		all = all[:t.k]
+	}
+
+	// rebuild the heap from the surviving counters
+	t.total += theirTotal
+	t.heap = t.heap[:0]
+	clear(t.index)
+	for _, c := range all {
+		t.heap = append(t.heap, c)
+		t.index[c.Key] = len(t.heap) - 1
+		t.up(len(t.heap) - 1)
+	}
+}
+
+// Reset drops every counter.
+func (t *TopK[K]) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total = 0
+	t.heap = t.heap[:0]
+	clear(t.index)
+}
+
+// byCount orders counters by descending count, then ascending error.
+func byCount[K comparable](a, b Counter[K]) int {
+	return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Error, b.Error))
+}
+
+// --- Internal Heap Math ---
+// The heap tracks each key's position so counts can be bumped in place,
+// which the general purpose heap package does not expose.
+
+func (t *TopK[K]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2 // parent
+		if t.heap[i].Count <= t.heap[j].Count {
+			break
+		}
+		t.swap(i, j)
+		j = i
+	}
+}
+
+func (t *TopK[K]) down(i int) {
+	n := len(t.heap)
+	for {
+		j := 2*i + 1 // left child
+		if j >= n {
+			break
+		}
+		if j2 := j + 1; j2 < n && t.heap[j2].Count < t.heap[j].Count {
+			j = j2 // right child
+		}
+		if t.heap[i].Count <= t.heap[j].Count {
+			break
+		}
+		t.swap(i, j)
+		i = j
+	}
+}
+
+func (t *TopK[K]) swap(i, j int) {
+	t.heap[i], t.heap[j] = t.heap[j], t.heap[i]
+	t.index[t.heap[i].Key] = i
+	t.index[t.heap[j].Key] = j
+}
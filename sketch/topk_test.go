@@ -0,0 +1,132 @@
+package sketch
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/wesleylin/basin/stream"
+)
+
+func TestTopK_ErrorBounds(t *testing.T) {
+	const k = 100
+	keys, truth := zipfKeys(200000)
+
+	tk := NewTopK[uint64](k)
+	for _, key := range keys {
+		tk.Add(key)
+	}
+
+	n := tk.Total()
+	bound := n / k
+	for _, c := range tk.Top() {
+		want := truth[c.Key]
+		if c.Count < want {
+			t.Fatalf("underestimate for %d: got %d, want >= %d", c.Key, c.Count, want)
+		}
+		if c.Count-want > bound || c.Error > bound {
+			t.Errorf("overestimate for %d beyond N/k: count %d, true %d, error %d", c.Key, c.Count, want, c.Error)
+		}
+		if c.Count-c.Error > want {
+			t.Errorf("true count %d below guaranteed floor %d", want, c.Count-c.Error)
+		}
+	}
+
+	// Every key with frequency above N/k must be tracked.
+	for key, want := range truth {
+		if want > bound {
+			if _, ok := tk.Estimate(key); !ok {
+				t.Errorf("heavy key %d (count %d) was not tracked", key, want)
+			}
+		}
+	}
+}
+
+func TestTopK_Order(t *testing.T) {
+	got, err := TopKOf(stream.FromSlice([]string{"a", "b", "a", "c", "a", "b"}), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Counter[string]{{"a", 3, 0}, {"b", 2, 0}, {"c", 1, 0}}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	errSource := errors.New("read failure")
+	s := stream.New(func(yield func(string) bool) {
+		yield("a")
+	}, &errSource)
+	if _, err := TopKOf(s, 2); err != errSource {
+		t.Errorf("expected %v, got %v", errSource, err)
+	}
+}
+
+func TestTopK_HeavyHitters(t *testing.T) {
+	tk := NewTopK[string](4)
+	tk.AddN("hot", 50)
+	tk.AddN("warm", 30)
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		tk.AddN(k, 4)
+	}
+
+	hh := tk.HeavyHitters(0.25)
+	if len(hh) != 2 || hh[0].Key != "hot" || hh[1].Key != "warm" {
+		t.Errorf("expected [hot warm], got %v", hh)
+	}
+}
+
+func TestTopK_Merge(t *testing.T) {
+	const k = 50
+	keys, truth := zipfKeys(100000)
+	a, b := NewTopK[uint64](k), NewTopK[uint64](k)
+	for i, key := range keys {
+		if i%3 == 0 {
+			a.Add(key)
+		} else {
+			b.Add(key)
+		}
+	}
+	a.Merge(b)
+
+	n := a.Total()
+	if n != uint64(len(keys)) {
+		t.Fatalf("expected merged total %d, got %d", len(keys), n)
+	}
+	if a.Len() > k {
+		t.Fatalf("merged tracker exceeds capacity: %d", a.Len())
+	}
+	bound := n / k
+	for _, c := range a.Top() {
+		want := truth[c.Key]
+		if c.Count < want || c.Count-want > bound {
+			t.Errorf("merged count for %d out of bounds: count %d, true %d, N/k %d", c.Key, c.Count, want, bound)
+		}
+	}
+	for key, want := range truth {
+		if want > bound {
+			if _, ok := a.Estimate(key); !ok {
+				t.Errorf("heavy key %d (count %d) lost in merge", key, want)
+			}
+		}
+	}
+}
+
+func TestTopK_Concurrency(t *testing.T) {
+	tk := NewTopK[int](10)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				tk.Add(i % (g + 2))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tk.Total() != 8000 {
+		t.Errorf("expected total 8000, got %d", tk.Total())
+	}
+}
@@ -0,0 +1,26 @@
+package sortedmap
+
+import (
+	"cmp"
+
+	"github.com/wesleylin/basin/stream"
+)
+
+// Collector gathers stream elements into a Map sorted by key.
+// A repeated key keeps the last value seen. Use it with stream.CollectWith.
+func Collector[T any, K cmp.Ordered, V any](keyFn func(T) K, valFn func(T) V) stream.Collector[T, *Map[K, V], *Map[K, V]] {
+	return stream.Collector[T, *Map[K, V], *Map[K, V]]{
+		Supplier: New[K, V],
+		Accumulator: func(m *Map[K, V], v T) *Map[K, V] {
+			m.Put(keyFn(v), valFn(v))
+			return m
+		},
+		Combiner: func(a, b *Map[K, V]) *Map[K, V] {
+			for k, v := range b.All() {
+				a.Put(k, v)
+			}
+			return a
+		},
+		Finisher: func(m *Map[K, V]) *Map[K, V] { return m },
+	}
+}
@@ -0,0 +1,14 @@
+package sortedmap
+
+import (
+	"cmp"
+
+	"github.com/wesleylin/basin/stream"
+)
+
+// Diff compares two maps in a single linear merge over their sorted keys and
+// yields every key that changed between from and to, in key order.
+// eq compares values; nil uses reflect.DeepEqual. See stream.DiffSorted.
+func Diff[K cmp.Ordered, V any](from, to *Map[K, V], eq func(a, b V) bool) stream.Stream2[K, stream.Change[V]] {
+	return stream.DiffSorted(from.All(), to.All(), eq)
+}
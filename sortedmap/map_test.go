@@ -2,7 +2,10 @@ package sortedmap
 
 import (
 	"fmt"
+	"slices"
 	"testing"
+
+	"github.com/wesleylin/basin/stream"
 )
 
 func TestSortedMap(t *testing.T) {
@@ -79,3 +82,47 @@ func TestSortedMap(t *testing.T) {
 		}
 	})
 }
+
+func TestSortedMapCollector(t *testing.T) {
+	words := []string{"pear", "fig", "apple"}
+	m, err := stream.CollectWith(stream.FromSlice(words), Collector(
+		func(w string) string { return w },
+		func(w string) int { return len(w) },
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var keys []string
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []string{"apple", "fig", "pear"}) {
+		t.Errorf("expected sorted keys, got %v", keys)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	v1 := New[int, string]()
+	v1.Put(1, "a")
+	v1.Put(2, "b")
+	v1.Put(4, "d")
+
+	v2 := New[int, string]()
+	v2.Put(2, "B")
+	v2.Put(3, "c")
+	v2.Put(4, "d")
+
+	got, err := Diff(v1, v2, nil).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []stream.Pair[int, stream.Change[string]]{
+		{Key: 1, Value: stream.Change[string]{Kind: stream.Removed, Old: "a"}},
+		{Key: 2, Value: stream.Change[string]{Kind: stream.Updated, Old: "b", New: "B"}},
+		{Key: 3, Value: stream.Change[string]{Kind: stream.Added, New: "c"}},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
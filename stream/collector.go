@@ -0,0 +1,206 @@
+package stream
+
+import "strings"
+
+// Collector describes a mutable reduction of a Stream[T] into a result R.
+//
+//   - Supplier creates a fresh accumulator.
+//   - Accumulator folds one element into the accumulator and returns it, so
+//     value types (int, structs) work as well as pointers and maps.
+//   - Combiner merges two partial accumulators. It is optional and only
+//     needed when partial results are built in parallel.
+//   - Finisher turns the accumulator into the final result.
+//
+// Container packages provide their own collectors, e.g. set.Collector or
+// sequencedmap.Collector, since they import stream and not the other way around.
+type Collector[T, A, R any] struct {
+	Supplier    func() A
+	Accumulator func(A, T) A
+	Combiner    func(A, A) A
+	Finisher    func(A) R
+}
+
+// CollectWith drains the stream into c and returns the finished result.
+func CollectWith[T, A, R any](s Stream[T], c Collector[T, A, R]) (R, error) {
+	seq, errp := s.open()
+	var zero R
+	acc := c.Supplier()
+	for v := range seq {
+		if *errp != nil {
+			break
+		}
+		acc = c.Accumulator(acc, v)
+	}
+	if err := *errp; err != nil {
+		return zero, err
+	}
+	return c.Finisher(acc), nil
+}
+
+// identity is the Finisher for collectors whose accumulator is the result.
+func identity[A any](a A) A { return a }
+
+// ToSlice collects elements into a slice in stream order.
+func ToSlice[T any]() Collector[T, []T, []T] {
+	return Collector[T, []T, []T]{
+		Supplier:    func() []T { return nil },
+		Accumulator: func(acc []T, v T) []T { return append(acc, v) },
+		Combiner:    func(a, b []T) []T { return append(a, b...) },
+		Finisher:    identity[[]T],
+	}
+}
+
+// Counting counts the elements.
+func Counting[T any]() Collector[T, int, int] {
+	return Collector[T, int, int]{
+		Supplier:    func() int { return 0 },
+		Accumulator: func(n int, _ T) int { return n + 1 },
+		Combiner:    func(a, b int) int { return a + b },
+		Finisher:    identity[int],
+	}
+}
+
+// Summing adds the elements together.
+func Summing[T Number]() Collector[T, T, T] {
+	return Collector[T, T, T]{
+		Supplier:    func() T { return 0 },
+		Accumulator: func(acc T, v T) T { return acc + v },
+		Combiner:    func(a, b T) T { return a + b },
+		Finisher:    identity[T],
+	}
+}
+
+// Summary holds the statistics gathered by Summarizing.
+// Min and Max are only meaningful when Count > 0.
+type Summary[T Number] struct {
+	Count int
+	Sum   T
+	Min   T
+	Max   T
+}
+
+// Average returns the arithmetic mean, or 0 for an empty summary.
+func (s Summary[T]) Average() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Sum) / float64(s.Count)
+}
+
+// Summarizing gathers count, sum, min and max in a single pass.
+func Summarizing[T Number]() Collector[T, Summary[T], Summary[T]] {
+	return Collector[T, Summary[T], Summary[T]]{
+		Supplier: func() Summary[T] { return Summary[T]{} },
+		Accumulator: func(s Summary[T], v T) Summary[T] {
+			if s.Count == 0 || v < s.Min {
+				s.Min = v
+			}
+			if s.Count == 0 || v > s.Max {
+				s.Max = v
+			}
+			s.Count++
+			s.Sum += v
+			return s
+		},
+		Combiner: func(a, b Summary[T]) Summary[T] {
+			if b.Count == 0 {
+				return a
+			}
+			if a.Count == 0 {
+				return b
+			}
+			a.Count += b.Count
+			a.Sum += b.Sum
+			a.Min = min(a.Min, b.Min)
+			a.Max = max(a.Max, b.Max)
+			return a
+		},
+		Finisher: identity[Summary[T]],
+	}
+}
+
+// joiner is the accumulator for Joining. started tracks whether a separator
+// is due, since an empty builder can't tell "nothing yet" from "an empty string".
+type joiner struct {
+	sb      strings.Builder
+	started bool
+}
+
+// Joining concatenates string elements with sep between them.
+func Joining[T ~string](sep string) Collector[T, *joiner, string] {
+	return Collector[T, *joiner, string]{
+		Supplier: func() *joiner { return &joiner{} },
+		Accumulator: func(j *joiner, v T) *joiner {
+			if j.started {
+				j.sb.WriteString(sep)
+			}
+			j.sb.WriteString(string(v))
+			j.started = true
+			return j
+		},
+		Combiner: func(a, b *joiner) *joiner {
+			if !b.started {
+				return a
+			}
+			if a.started {
+				a.sb.WriteString(sep)
+			}
+			a.sb.WriteString(b.sb.String())
+			a.started = true
+			return a
+		},
+		Finisher: func(j *joiner) string { return j.sb.String() },
+	}
+}
+
+// Mapping adapts a downstream collector by transforming each element first.
+// Useful inside GroupingBy, e.g. summing one field per key.
+func Mapping[T, U, A, R any](fn func(T) U, downstream Collector[U, A, R]) Collector[T, A, R] {
+	return Collector[T, A, R]{
+		Supplier: downstream.Supplier,
+		Accumulator: func(acc A, v T) A {
+			return downstream.Accumulator(acc, fn(v))
+		},
+		Combiner: downstream.Combiner,
+		Finisher: downstream.Finisher,
+	}
+}
+
+// GroupingBy groups elements by key and reduces each group with downstream.
+// For example GroupingBy(keyFn, Counting[T]()) counts per key.
+func GroupingBy[T any, K comparable, A, R any](keyFn func(T) K, downstream Collector[T, A, R]) Collector[T, map[K]A, map[K]R] {
+	var combiner func(a, b map[K]A) map[K]A
+	if downstream.Combiner != nil {
+		combiner = func(a, b map[K]A) map[K]A {
+			for k, bAcc := range b {
+				if aAcc, ok := a[k]; ok {
+					a[k] = downstream.Combiner(aAcc, bAcc)
+				} else {
+					a[k] = bAcc
+				}
+			}
+			return a
+		}
+	}
+
+	return Collector[T, map[K]A, map[K]R]{
+		Supplier: func() map[K]A { return make(map[K]A) },
+		Accumulator: func(groups map[K]A, v T) map[K]A {
+			k := keyFn(v)
+			acc, ok := groups[k]
+			if !ok {
+				acc = downstream.Supplier()
+			}
+			groups[k] = downstream.Accumulator(acc, v)
+			return groups
+		},
+		Combiner: combiner,
+		Finisher: func(groups map[K]A) map[K]R {
+			res := make(map[K]R, len(groups))
+			for k, acc := range groups {
+				res[k] = downstream.Finisher(acc)
+			}
+			return res
+		},
+	}
+}
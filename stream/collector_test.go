@@ -0,0 +1,89 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCollectWith(t *testing.T) {
+	t.Run("ToSlice", func(t *testing.T) {
+		got, err := CollectWith(FromSlice([]int{3, 1, 2}), ToSlice[int]())
+		if err != nil || !slices.Equal(got, []int{3, 1, 2}) {
+			t.Errorf("expected [3 1 2], got %v (err: %v)", got, err)
+		}
+	})
+
+	t.Run("Counting and Summing", func(t *testing.T) {
+		n, _ := CollectWith(FromSlice([]string{"a", "b"}), Counting[string]())
+		sum, _ := CollectWith(FromSlice([]float64{1.5, 2.5}), Summing[float64]())
+		if n != 2 || sum != 4.0 {
+			t.Errorf("expected 2 and 4.0, got %d and %v", n, sum)
+		}
+	})
+
+	t.Run("Summarizing", func(t *testing.T) {
+		s, err := CollectWith(FromSlice([]int{4, -2, 10}), Summarizing[int]())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := Summary[int]{Count: 3, Sum: 12, Min: -2, Max: 10}
+		if s != want || s.Average() != 4.0 {
+			t.Errorf("expected %+v, got %+v", want, s)
+		}
+	})
+
+	t.Run("Joining keeps empty strings", func(t *testing.T) {
+		got, _ := CollectWith(FromSlice([]string{"", "a", "b"}), Joining[string](","))
+		if got != ",a,b" {
+			t.Errorf("expected %q, got %q", ",a,b", got)
+		}
+	})
+
+	t.Run("GroupingBy with downstream", func(t *testing.T) {
+		type Sale struct {
+			Region string
+			Amount int
+		}
+		sales := []Sale{{"east", 10}, {"west", 5}, {"east", 7}}
+
+		counts, _ := CollectWith(FromSlice(sales), GroupingBy(func(s Sale) string { return s.Region }, Counting[Sale]()))
+		if counts["east"] != 2 || counts["west"] != 1 {
+			t.Errorf("count per key failed: %v", counts)
+		}
+
+		totals, _ := CollectWith(FromSlice(sales), GroupingBy(
+			func(s Sale) string { return s.Region },
+			Mapping(func(s Sale) int { return s.Amount }, Summing[int]()),
+		))
+		if totals["east"] != 17 || totals["west"] != 5 {
+			t.Errorf("sum per key failed: %v", totals)
+		}
+	})
+
+	t.Run("Combiner merges partial results", func(t *testing.T) {
+		c := GroupingBy(func(n int) bool { return n%2 == 0 }, Summarizing[int]())
+		a, b := c.Supplier(), c.Supplier()
+		for _, n := range []int{1, 2, 3} {
+			a = c.Accumulator(a, n)
+		}
+		for _, n := range []int{4, 5} {
+			b = c.Accumulator(b, n)
+		}
+		got := c.Finisher(c.Combiner(a, b))
+		if got[true].Sum != 6 || got[false].Count != 3 || got[false].Max != 5 {
+			t.Errorf("unexpected combined result: %+v", got)
+		}
+	})
+
+	t.Run("Error Propagation", func(t *testing.T) {
+		var errSource = errors.New("read failure")
+		s := New(func(yield func(int) bool) {
+			yield(1)
+		}, &errSource)
+
+		if _, err := CollectWith(s, Counting[int]()); err != errSource {
+			t.Errorf("expected %v, got %v", errSource, err)
+		}
+	})
+}
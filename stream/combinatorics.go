@@ -0,0 +1,152 @@
+package stream
+
+// Combinatorial sources are lazy: each tuple is built only when it is pulled,
+// so stopping early with Take or First costs only what was yielded.
+//
+// Combinations, Permutations and PowerSet reuse a single slice for every tuple
+// they yield, which is safe for consumers that look at one tuple at a time
+// (Filter, First, Count...). Pass CopyOnYield to get a fresh slice per tuple
+// when the tuples are retained: without it, Collect returns the same slice
+// over and over, holding only the last tuple.
+
+// ComboOption configures Combinations, Permutations and PowerSet.
+type ComboOption func(*comboConfig)
+
+type comboConfig struct {
+	copy bool
+}
+
+// CopyOnYield makes every yielded tuple a new slice that the consumer may keep.
+func CopyOnYield() ComboOption {
+	return func(c *comboConfig) { c.copy = true }
+}
+
+// emitter fills the shared buffer from the chosen indices and yields it.
+type emitter[T any] struct {
+	items []T
+	buf   []T
+	copy  bool
+}
+
+func newEmitter[T any](items []T, opts []ComboOption) *emitter[T] {
+	var cfg comboConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &emitter[T]{items: items, copy: cfg.copy}
+}
+
+func (e *emitter[T]) emit(idx []int, yield func([]T) bool) bool {
+	// PowerSet grows the tuple size as it goes
+	if e.copy || cap(e.buf) < len(idx) {
+		e.buf = make([]T, len(idx))
+	}
+	e.buf = e.buf[:len(idx)]
+	for i, j := range idx {
+		e.buf[i] = e.items[j]
+	}
+	return yield(e.buf)
+}
+
+// Product yields every pair (x, y) with x from a and y from b,
+// in row-major order: all of b for a[0], then all of b for a[1], and so on.
+func Product[A, B any](a []A, b []B) Stream2[A, B] {
+	return FromSeq2(func(yield func(A, B) bool) {
+		for _, x := range a {
+			for _, y := range b {
+				if !yield(x, y) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// Combinations yields every k-element subset of items, preserving their
+// relative order, in lexicographic order of positions. Combinations of 0
+// elements yield one empty tuple; k greater than len(items) yields nothing.
+// Tuples share one buffer unless CopyOnYield is passed.
+func Combinations[T any](items []T, k int, opts ...ComboOption) Stream[[]T] {
+	return FromSeq(func(yield func([]T) bool) {
+		combinations(newEmitter(items, opts), len(items), k, yield)
+	})
+}
+
+func combinations[T any](e *emitter[T], n, k int, yield func([]T) bool) bool {
+	if k < 0 || k > n {
+		return true
+	}
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	for {
+		if !e.emit(idx, yield) {
+			return false
+		}
+		// advance the rightmost index that still has room to move
+		i := k - 1
+		for i >= 0 && idx[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// Permutations yields every ordering of items in lexicographic order of
+// positions, so a sorted input yields its permutations in sorted order.
+// Equal elements are treated as distinct. Tuples share one buffer unless
+// CopyOnYield is passed.
+func Permutations[T any](items []T, opts ...ComboOption) Stream[[]T] {
+	return FromSeq(func(yield func([]T) bool) {
+		e := newEmitter(items, opts)
+		n := len(items)
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		for {
+			if !e.emit(idx, yield) {
+				return
+			}
+			// next permutation: find the rightmost ascent...
+			i := n - 2
+			for i >= 0 && idx[i] >= idx[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			// ...swap it with the smallest larger element to its right...
+			j := n - 1
+			for idx[j] <= idx[i] {
+				j--
+			}
+			idx[i], idx[j] = idx[j], idx[i]
+			// ...and put the suffix back in ascending order
+			for l, r := i+1, n-1; l < r; l, r = l+1, r-1 {
+				idx[l], idx[r] = idx[r], idx[l]
+			}
+		}
+	})
+}
+
+// PowerSet yields every subset of items: the empty set first, then subsets by
+// increasing size, each size in the order of Combinations. Tuples share one
+// buffer unless CopyOnYield is passed.
+func PowerSet[T any](items []T, opts ...ComboOption) Stream[[]T] {
+	return FromSeq(func(yield func([]T) bool) {
+		e := newEmitter(items, opts)
+		for k := 0; k <= len(items); k++ {
+			if !combinations(e, len(items), k, yield) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package stream
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestProduct(t *testing.T) {
+	got, err := Product([]int{1, 2}, []string{"a", "b", "c"}).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Pair[int, string]{{1, "a"}, {1, "b"}, {1, "c"}, {2, "a"}, {2, "b"}, {2, "c"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	got, err := Combinations([]int{1, 2, 3, 4}, 2, CopyOnYield()).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, tc := range []struct {
+		k, want int
+	}{{0, 1}, {4, 1}, {5, 0}, {-1, 0}} {
+		n, _ := Combinations([]int{1, 2, 3, 4}, tc.k).Count()
+		if n != tc.want {
+			t.Errorf("k=%d: expected %d combinations, got %d", tc.k, tc.want, n)
+		}
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	got, err := Permutations([]string{"a", "b", "c"}, CopyOnYield()).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]string{
+		{"a", "b", "c"}, {"a", "c", "b"}, {"b", "a", "c"},
+		{"b", "c", "a"}, {"c", "a", "b"}, {"c", "b", "a"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// equal elements are treated as distinct: 4! orderings
+	n, _ := Permutations([]int{1, 1, 2, 2}).Count()
+	if n != 24 {
+		t.Errorf("expected 24 permutations, got %d", n)
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	got, err := PowerSet([]int{1, 2, 3}, CopyOnYield()).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCombinatorics_Laziness(t *testing.T) {
+	t.Run("Buffer Is Reused By Default", func(t *testing.T) {
+		var first []int
+		Permutations([]int{1, 2, 3}).ForEach(func(p []int) {
+			if first == nil {
+				first = p
+			}
+		})
+		// the slice from the first yield was overwritten by later tuples
+		if slices.Equal(first, []int{1, 2, 3}) {
+			t.Errorf("expected the shared buffer to be reused, got %v", first)
+		}
+	})
+
+	t.Run("Default Mode Yields Every Tuple", func(t *testing.T) {
+		// inspect each tuple as it goes by, without retaining it
+		check := func(name string, s Stream[[]int], want [][]int) {
+			t.Helper()
+			i := 0
+			err := s.ForEach(func(tuple []int) {
+				if i >= len(want) || !slices.Equal(tuple, want[i]) {
+					t.Errorf("%s: unexpected tuple %d: %v", name, i, tuple)
+				}
+				i++
+			})
+			if err != nil || i != len(want) {
+				t.Errorf("%s: expected %d tuples, got %d (err: %v)", name, len(want), i, err)
+			}
+		}
+
+		check("PowerSet", PowerSet([]int{1, 2, 3}),
+			[][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}})
+		check("Combinations", Combinations([]int{1, 2, 3}, 2),
+			[][]int{{1, 2}, {1, 3}, {2, 3}})
+		check("Permutations", Permutations([]int{1, 2, 3}),
+			[][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}})
+
+		if n, err := PowerSet([]int{1, 2, 3}).Count(); err != nil || n != 8 {
+			t.Errorf("expected 8 subsets, got %d (err: %v)", n, err)
+		}
+	})
+
+	t.Run("Early Exit", func(t *testing.T) {
+		// 20! permutations would never finish if materialized
+		items := make([]int, 20)
+		for i := range items {
+			items[i] = i
+		}
+		perm, err := Permutations(items).Filter(func(p []int) bool { return p[19] == 18 }).First()
+		if err != nil || perm[18] != 19 {
+			t.Errorf("expected the first permutation ending in 18, got %v (err: %v)", perm, err)
+		}
+
+		got, err := PowerSet(items, CopyOnYield()).Take(3).Collect()
+		if err != nil || len(got) != 3 {
+			t.Errorf("expected 3 subsets, got %v (err: %v)", got, err)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package stream
+
+import "iter"
+
+// Cursor is a pull-style reader over a stream, for code that can't be written
+// as a range loop, such as hand-written parsers:
+//
+//	c := s.Cursor()
+//	defer c.Close()
+//	for c.Next() {
+//		v := c.Value()
+//		...
+//	}
+//	if err := c.Err(); err != nil { ... }
+//
+// A Cursor runs the stream in a coroutine (iter.Pull). Always Close it, or
+// drain it, to release that coroutine. A Cursor is not safe for concurrent use.
+type Cursor[T any] struct {
+	next func() (T, bool)
+	stop func()
+	errp *error
+
+	cur     T
+	hasCur  bool
+	pending []T // pushed back by Peek and Unread, last in first out
+	done    bool
+}
+
+// Cursor starts a new run of s and returns a cursor over it.
+func (s Stream[T]) Cursor() *Cursor[T] {
+	seq, errp := s.open()
+	next, stop := iter.Pull(seq)
+	return &Cursor[T]{next: next, stop: stop, errp: errp}
+}
+
+// Next advances to the next element, reporting false once the stream is
+// exhausted, has failed or the cursor is closed.
+func (c *Cursor[T]) Next() bool {
+	c.cur, c.hasCur = c.pull()
+	return c.hasCur
+}
+
+// Value returns the element Next advanced to.
+func (c *Cursor[T]) Value() T {
+	return c.cur
+}
+
+// Peek returns the element the next call to Next would advance to, without
+// consuming it. It reports false if there is none.
+func (c *Cursor[T]) Peek() (T, bool) {
+	v, ok := c.pull()
+	if ok {
+		c.pending = append(c.pending, v)
+	}
+	return v, ok
+}
+
+// Unread pushes the current value back, so the next call to Next returns it
+// again. It panics if there is no current value, e.g. it was already unread.
+func (c *Cursor[T]) Unread() {
+	if !c.hasCur {
+		panic("stream: Unread without a current value")
+	}
+	c.pending = append(c.pending, c.cur)
+	c.hasCur = false
+}
+
+// Err returns the error that stopped the stream, if any.
+func (c *Cursor[T]) Err() error {
+	return *c.errp
+}
+
+// Close stops the underlying iterator, running its cleanups, and returns
+// the stream's error like Err. It is safe to call more than once and at any point.
+func (c *Cursor[T]) Close() error {
+	c.finish()
+	c.pending = nil
+	return c.Err()
+}
+
+// pull returns the next element, from the pushed back ones first.
+func (c *Cursor[T]) pull() (T, bool) {
+	if n := len(c.pending); n > 0 {
+		v := c.pending[n-1]
+		c.pending = c.pending[:n-1]
+		return v, true
+	}
+	var zero T
+	if c.done {
+		return zero, false
+	}
+	v, ok := c.next()
+	if !ok || *c.errp != nil {
+		c.finish()
+		return zero, false
+	}
+	return v, true
+}
+
+func (c *Cursor[T]) finish() {
+	if !c.done {
+		c.done = true
+		c.stop()
+	}
+}
@@ -0,0 +1,104 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("Next And Value", func(t *testing.T) {
+		c := FromSlice([]int{1, 2, 3}).Cursor()
+		defer c.Close()
+
+		var got []int
+		for c.Next() {
+			got = append(got, c.Value())
+		}
+		if err := c.Err(); err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v (err: %v)", got, err)
+		}
+		if c.Next() {
+			t.Error("expected Next to stay false once exhausted")
+		}
+	})
+
+	t.Run("Peek And Unread", func(t *testing.T) {
+		c := FromSlice([]int{1, 2, 3}).Cursor()
+		defer c.Close()
+
+		if v, ok := c.Peek(); !ok || v != 1 {
+			t.Fatalf("expected to peek 1, got %d, %v", v, ok)
+		}
+		if !c.Next() || c.Value() != 1 {
+			t.Fatalf("expected Next to return the peeked 1, got %d", c.Value())
+		}
+		if v, _ := c.Peek(); v != 2 {
+			t.Errorf("expected to peek 2, got %d", v)
+		}
+		c.Unread()
+
+		var got []int
+		for c.Next() {
+			got = append(got, c.Value())
+		}
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("expected the unread 1 before the peeked 2, got %v", got)
+		}
+		if _, ok := c.Peek(); ok {
+			t.Error("expected nothing to peek at the end")
+		}
+	})
+
+	t.Run("Unread Without Value Panics", func(t *testing.T) {
+		c := FromSlice([]int{1}).Cursor()
+		defer c.Close()
+		defer func() {
+			if recover() == nil {
+				t.Error("expected Unread to panic")
+			}
+		}()
+		c.Unread()
+	})
+
+	t.Run("Reports Live Wire Error", func(t *testing.T) {
+		boom := errors.New("boom")
+		c := MapErr(FromSlice([]int{1, 2, 3}), func(n int) (int, error) {
+			if n == 2 {
+				return 0, boom
+			}
+			return n, nil
+		}).Cursor()
+		defer c.Close()
+
+		n := 0
+		for c.Next() {
+			n++
+		}
+		if n != 1 || !errors.Is(c.Err(), boom) {
+			t.Errorf("expected 1 item and boom, got %d and %v", n, c.Err())
+		}
+	})
+
+	t.Run("Close Early Runs Cleanups", func(t *testing.T) {
+		closed := false
+		c := FromSlice([]int{1, 2, 3}).OnClose(func() error {
+			closed = true
+			return nil
+		}).Cursor()
+
+		c.Next()
+		if err := c.Close(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !closed {
+			t.Error("expected Close to stop the stream and run its cleanup")
+		}
+		if c.Next() {
+			t.Error("expected Next to be false after Close")
+		}
+		if err := c.Close(); err != nil {
+			t.Errorf("expected a second Close to be harmless, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package stream
+
+import (
+	"cmp"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// ChangeKind says how a key differs between two maps.
+type ChangeKind int
+
+const (
+	Added   ChangeKind = iota // only in the new map; New is set
+	Removed                   // only in the old map; Old is set
+	Updated                   // in both with unequal values; Old and New are set
+	Moved                     // in both, but reordered (sequenced maps only); Old and New are set
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	case Updated:
+		return "Updated"
+	case Moved:
+		return "Moved"
+	}
+	return "ChangeKind(?)"
+}
+
+// Change describes how one key differs between an old and a new map.
+type Change[V any] struct {
+	Kind ChangeKind
+	Old  V
+	New  V
+}
+
+// The diff functions yield (key, Change) for every key that differs.
+// Value equality is pluggable: eq reports whether two values are the same;
+// a nil eq uses reflect.DeepEqual. Container packages wrap these for their
+// own maps, e.g. sequencedmap.Diff and sortedmap.Diff.
+
+func orDeepEqual[V any](eq func(a, b V) bool) func(a, b V) bool {
+	if eq == nil {
+		return func(a, b V) bool { return reflect.DeepEqual(a, b) }
+	}
+	return eq
+}
+
+// DiffMaps compares two plain Go maps. Since Go maps are unordered, the
+// changes come in no particular order and nothing is reported as Moved.
+func DiffMaps[K comparable, V any](from, to map[K]V, eq func(a, b V) bool) Stream2[K, Change[V]] {
+	eq = orDeepEqual(eq)
+	return FromSeq2(func(yield func(K, Change[V]) bool) {
+		for k, ov := range from {
+			nv, ok := to[k]
+			switch {
+			case !ok:
+				if !yield(k, Change[V]{Kind: Removed, Old: ov}) {
+					return
+				}
+			case !eq(ov, nv):
+				if !yield(k, Change[V]{Kind: Updated, Old: ov, New: nv}) {
+					return
+				}
+			}
+		}
+		for k, nv := range to {
+			if _, ok := from[k]; !ok {
+				if !yield(k, Change[V]{Kind: Added, New: nv}) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// DiffSorted compares two sequences sorted by ascending key, such as the All
+// iterators of two sorted maps, with a single linear merge. Changes are
+// yielded in key order, without buffering either side.
+func DiffSorted[K cmp.Ordered, V any](from, to iter.Seq2[K, V], eq func(a, b V) bool) Stream2[K, Change[V]] {
+	eq = orDeepEqual(eq)
+	return FromSeq2(func(yield func(K, Change[V]) bool) {
+		nextOld, stopOld := iter.Pull2(from)
+		defer stopOld()
+		nextNew, stopNew := iter.Pull2(to)
+		defer stopNew()
+
+		ko, ov, okOld := nextOld()
+		kn, nv, okNew := nextNew()
+		for okOld || okNew {
+			var c int
+			switch {
+			case !okNew:
+				c = -1
+			case !okOld:
+				c = 1
+			default:
+				c = cmp.Compare(ko, kn)
+			}
+
+			switch {
+			case c < 0:
+				if !yield(ko, Change[V]{Kind: Removed, Old: ov}) {
+					return
+				}
+				ko, ov, okOld = nextOld()
+			case c > 0:
+				if !yield(kn, Change[V]{Kind: Added, New: nv}) {
+					return
+				}
+				kn, nv, okNew = nextNew()
+			default:
+				if !eq(ov, nv) && !yield(kn, Change[V]{Kind: Updated, Old: ov, New: nv}) {
+					return
+				}
+				ko, ov, okOld = nextOld()
+				kn, nv, okNew = nextNew()
+			}
+		}
+	})
+}
+
+// DiffSequenced compares two insertion-ordered sequences, such as the All
+// iterators of two sequenced maps. Removed keys come first, in old order, then
+// the new map is walked in order reporting Added, Updated and Moved keys.
+//
+// Moved is reported for the fewest keys that explain the reordering: the keys
+// whose relative order is unchanged form a longest increasing subsequence and
+// stay put, every other shared key moved. A key that moved and changed value
+// yields an Updated and a Moved change.
+func DiffSequenced[K comparable, V any](from, to iter.Seq2[K, V], eq func(a, b V) bool) Stream2[K, Change[V]] {
+	eq = orDeepEqual(eq)
+	return FromSeq2(func(yield func(K, Change[V]) bool) {
+		type entry struct {
+			pos int
+			val V
+		}
+		before := make(map[K]entry)
+		var oldKeys []K
+		for k, v := range from {
+			before[k] = entry{pos: len(oldKeys), val: v}
+			oldKeys = append(oldKeys, k)
+		}
+
+		var keys []K
+		var vals []V
+		after := make(map[K]struct{})
+		for k, v := range to {
+			keys = append(keys, k)
+			vals = append(vals, v)
+			after[k] = struct{}{}
+		}
+
+		for _, k := range oldKeys {
+			if _, ok := after[k]; !ok {
+				if !yield(k, Change[V]{Kind: Removed, Old: before[k].val}) {
+					return
+				}
+			}
+		}
+
+		// old positions of the shared keys, in new order
+		var shared []int
+		for _, k := range keys {
+			if e, ok := before[k]; ok {
+				shared = append(shared, e.pos)
+			}
+		}
+		stayed := longestIncreasing(shared)
+
+		for i, k := range keys {
+			e, ok := before[k]
+			if !ok {
+				if !yield(k, Change[V]{Kind: Added, New: vals[i]}) {
+					return
+				}
+				continue
+			}
+			if !eq(e.val, vals[i]) && !yield(k, Change[V]{Kind: Updated, Old: e.val, New: vals[i]}) {
+				return
+			}
+			if _, ok := stayed[e.pos]; !ok && !yield(k, Change[V]{Kind: Moved, Old: e.val, New: vals[i]}) {
+				return
+			}
+		}
+	})
+}
+
+// longestIncreasing returns the values of a longest strictly increasing
+// subsequence of xs, in O(n log n).
+func longestIncreasing(xs []int) map[int]struct{} {
+	var tails []int // tails[l] = index in xs of the smallest tail of a run of length l+1
+	prev := make([]int, len(xs))
+	for i, x := range xs {
+		l, _ := slices.BinarySearchFunc(tails, x, func(j, x int) int { return cmp.Compare(xs[j], x) })
+		if l > 0 {
+			prev[i] = tails[l-1]
+		} else {
+			prev[i] = -1
+		}
+		if l == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[l] = i
+		}
+	}
+
+	res := make(map[int]struct{}, len(tails))
+	if len(tails) == 0 {
+		return res
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		res[xs[i]] = struct{}{}
+	}
+	return res
+}
@@ -0,0 +1,111 @@
+package stream
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type change = Pair[string, Change[int]]
+
+func kvs(pairs ...any) func(yield func(string, int) bool) {
+	return func(yield func(string, int) bool) {
+		for i := 0; i < len(pairs); i += 2 {
+			if !yield(pairs[i].(string), pairs[i+1].(int)) {
+				return
+			}
+		}
+	}
+}
+
+func TestDiffMaps(t *testing.T) {
+	from := map[string]int{"a": 1, "b": 2, "c": 3}
+	to := map[string]int{"a": 1, "b": 20, "d": 4}
+
+	got, err := DiffMaps(from, to, nil).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slices.SortFunc(got, func(x, y change) int { return strings.Compare(x.Key, y.Key) })
+	want := []change{
+		{"b", Change[int]{Kind: Updated, Old: 2, New: 20}},
+		{"c", Change[int]{Kind: Removed, Old: 3}},
+		{"d", Change[int]{Kind: Added, New: 4}},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// identical maps have no changes
+	if n, _ := DiffMaps(from, maps.Clone(from), nil).Count(); n != 0 {
+		t.Errorf("expected no changes, got %d", n)
+	}
+}
+
+func TestDiffSorted(t *testing.T) {
+	from := kvs("a", 1, "c", 3, "e", 5)
+	to := kvs("b", 2, "c", 30, "e", 5, "f", 6)
+
+	got, err := DiffSorted(from, to, nil).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []change{
+		{"a", Change[int]{Kind: Removed, Old: 1}},
+		{"b", Change[int]{Kind: Added, New: 2}},
+		{"c", Change[int]{Kind: Updated, Old: 3, New: 30}},
+		{"f", Change[int]{Kind: Added, New: 6}},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDiffSequenced(t *testing.T) {
+	t.Run("Detects Moves", func(t *testing.T) {
+		// c moved to the front, b was removed, e was added, d changed
+		from := kvs("a", 1, "b", 2, "c", 3, "d", 4)
+		to := kvs("c", 3, "a", 1, "d", 40, "e", 5)
+
+		got, err := DiffSequenced(from, to, nil).Collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []change{
+			{"b", Change[int]{Kind: Removed, Old: 2}},
+			{"c", Change[int]{Kind: Moved, Old: 3, New: 3}},
+			{"d", Change[int]{Kind: Updated, Old: 4, New: 40}},
+			{"e", Change[int]{Kind: Added, New: 5}},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Removal Alone Is Not A Move", func(t *testing.T) {
+		got, _ := DiffSequenced(kvs("a", 1, "b", 2, "c", 3), kvs("a", 1, "c", 3), nil).Collect()
+		if len(got) != 1 || got[0].Value.Kind != Removed {
+			t.Errorf("expected only b removed, got %v", got)
+		}
+	})
+
+	t.Run("Pluggable Equality", func(t *testing.T) {
+		sameParity := func(a, b int) bool { return a%2 == b%2 }
+		got, _ := DiffSequenced(kvs("a", 1, "b", 2), kvs("a", 3, "b", 5), sameParity).Collect()
+		want := []change{{"b", Change[int]{Kind: Updated, Old: 2, New: 5}}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestLongestIncreasing(t *testing.T) {
+	got := slices.Sorted(maps.Keys(longestIncreasing([]int{2, 0, 3, 1, 4})))
+	if len(got) != 3 || !slices.IsSorted(got) {
+		t.Errorf("expected a run of 3, got %v", got)
+	}
+	if n := len(longestIncreasing(nil)); n != 0 {
+		t.Errorf("expected an empty result, got %d", n)
+	}
+}
@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"io"
+	"iter"
+)
+
+// FromNext builds a stream from a function that returns one item per call,
+// such as a decoder's Next. next reports ok=false once there are no more
+// items; a non-nil error trips the live wire and ends the stream.
+func FromNext[T any](next func() (T, bool, error)) Stream[T] {
+	return Stream[T]{
+		run: func(errp *error) iter.Seq[T] {
+			return func(yield func(T) bool) {
+				for {
+					v, ok, err := next()
+					if err != nil {
+						*errp = err
+						return
+					}
+					if !ok || !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// FromNext2 is FromNext for key-value items.
+func FromNext2[K, V any](next func() (K, V, bool, error)) Stream2[K, V] {
+	return Stream2[K, V]{
+		run: func(errp *error) iter.Seq2[K, V] {
+			return func(yield func(K, V) bool) {
+				for {
+					k, v, ok, err := next()
+					if err != nil {
+						*errp = err
+						return
+					}
+					if !ok || !yield(k, v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Rows is the Next/Err half of the cursor pattern used by *sql.Rows.
+type Rows interface {
+	Next() bool
+	Err() error
+}
+
+// FromRows streams the rows of a Next/Scan/Err cursor such as *sql.Rows.
+// scan reads the current row, usually by calling rows.Scan; a scan error
+// ends the stream like an error from rows.Err. If rows is an io.Closer it is
+// closed when the stream ends, however it ends, and a Close error is reported.
+func FromRows[T any](rows Rows, scan func() (T, error)) Stream[T] {
+	s := FromNext(func() (T, bool, error) {
+		var zero T
+		if !rows.Next() {
+			return zero, false, rows.Err()
+		}
+		v, err := scan()
+		return v, err == nil, err
+	})
+	if c, ok := rows.(io.Closer); ok {
+		return s.OnClose(c.Close)
+	}
+	return s
+}
+
+// Scanner is the Scan/Err half of the pattern used by *bufio.Scanner.
+type Scanner interface {
+	Scan() bool
+	Err() error
+}
+
+// FromScanner streams the tokens of a Scan/Err reader such as *bufio.Scanner.
+// value reads the current token, e.g. sc.Text or sc.Bytes. Note that
+// bufio.Scanner.Bytes reuses its buffer, so copy the bytes to keep them.
+func FromScanner[T any](sc Scanner, value func() T) Stream[T] {
+	return FromNext(func() (T, bool, error) {
+		var zero T
+		if !sc.Scan() {
+			return zero, false, sc.Err()
+		}
+		return value(), true, nil
+	})
+}
@@ -0,0 +1,134 @@
+package stream
+
+import (
+	"bufio"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFromNext(t *testing.T) {
+	t.Run("Yields Until Done", func(t *testing.T) {
+		i := 0
+		got, err := FromNext(func() (int, bool, error) {
+			i++
+			return i, i <= 3, nil
+		}).Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v (err: %v)", got, err)
+		}
+	})
+
+	t.Run("Error Trips The Wire", func(t *testing.T) {
+		boom := errors.New("boom")
+		i := 0
+		var seen []int
+		err := FromNext(func() (int, bool, error) {
+			i++
+			if i == 3 {
+				return 0, false, boom
+			}
+			return i, true, nil
+		}).ForEach(func(n int) { seen = append(seen, n) })
+		if !errors.Is(err, boom) || !slices.Equal(seen, []int{1, 2}) {
+			t.Errorf("expected [1 2] and boom, got %v and %v", seen, err)
+		}
+	})
+}
+
+func TestFromNext2(t *testing.T) {
+	keys := []string{"a", "b"}
+	i := 0
+	got, err := FromNext2(func() (string, int, bool, error) {
+		if i == len(keys) {
+			return "", 0, false, nil
+		}
+		i++
+		return keys[i-1], i, true, nil
+	}).Collect()
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	if err != nil || !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v (err: %v)", want, got, err)
+	}
+}
+
+// sqlRows mimics *sql.Rows.
+type sqlRows struct {
+	data    []int
+	pos     int
+	err     error
+	closed  bool
+	scanErr error
+}
+
+func (r *sqlRows) Next() bool {
+	if r.pos >= len(r.data) {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+func (r *sqlRows) Scan(dest *int) error {
+	if r.scanErr != nil {
+		return r.scanErr
+	}
+	*dest = r.data[r.pos-1]
+	return nil
+}
+
+func (r *sqlRows) Err() error   { return r.err }
+func (r *sqlRows) Close() error { r.closed = true; return nil }
+
+func TestFromRows(t *testing.T) {
+	scanInt := func(rows *sqlRows) func() (int, error) {
+		return func() (int, error) {
+			var n int
+			err := rows.Scan(&n)
+			return n, err
+		}
+	}
+
+	t.Run("Scans And Closes", func(t *testing.T) {
+		rows := &sqlRows{data: []int{1, 2, 3}}
+		got, err := FromRows(rows, scanInt(rows)).Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v (err: %v)", got, err)
+		}
+		if !rows.closed {
+			t.Error("expected rows to be closed")
+		}
+	})
+
+	t.Run("Closes On Early Exit", func(t *testing.T) {
+		rows := &sqlRows{data: []int{1, 2, 3}}
+		if _, err := FromRows(rows, scanInt(rows)).First(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !rows.closed {
+			t.Error("expected rows to be closed")
+		}
+	})
+
+	t.Run("Reports Rows And Scan Errors", func(t *testing.T) {
+		boom := errors.New("boom")
+		rows := &sqlRows{data: []int{1}, err: boom}
+		if _, err := FromRows(rows, scanInt(rows)).Collect(); !errors.Is(err, boom) {
+			t.Errorf("expected rows.Err, got %v", err)
+		}
+
+		rows = &sqlRows{data: []int{1}, scanErr: boom}
+		if _, err := FromRows(rows, scanInt(rows)).Collect(); !errors.Is(err, boom) {
+			t.Errorf("expected the scan error, got %v", err)
+		}
+	})
+}
+
+func TestFromScanner(t *testing.T) {
+	sc := bufio.NewScanner(strings.NewReader("one\ntwo\nthree\n"))
+	got, err := FromScanner(sc, sc.Text).Collect()
+	if err != nil || !slices.Equal(got, []string{"one", "two", "three"}) {
+		t.Errorf("expected [one two three], got %v (err: %v)", got, err)
+	}
+}
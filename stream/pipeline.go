@@ -0,0 +1,114 @@
+package stream
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Pipeline is a reusable chain of operators from Stream[T] to Stream[R].
+// It is defined once and applied to any number of sources:
+//
+//	evens := stream.PipeMap(
+//		stream.NewPipeline[int]().Filter(isEven),
+//		strconv.Itoa,
+//	).Take(10)
+//
+//	a, err := evens.Apply(stream.FromSlice(xs)).Collect()
+//	b, err := evens.Apply(other).Collect()
+//
+// Operators that keep the element type are methods; the ones that change it
+// are functions (PipeMap, PipeFlatMap, Then), since methods can't have type
+// parameters. Pipelines are immutable: every step returns a new one.
+// On an instrumented source each step is measured as a Stage named after it.
+type Pipeline[T, R any] struct {
+	names []string
+	apply func(Stream[T]) Stream[R]
+}
+
+// NewPipeline returns an empty pipeline that passes elements through unchanged.
+func NewPipeline[T any]() Pipeline[T, T] {
+	return Pipeline[T, T]{
+		apply: func(s Stream[T]) Stream[T] { return s },
+	}
+}
+
+// Apply runs the pipeline's stages on src.
+func (p Pipeline[T, R]) Apply(src Stream[T]) Stream[R] {
+	return p.apply(src)
+}
+
+// Describe returns the stage chain, e.g. "Filter -> Map -> Take(10)".
+func (p Pipeline[T, R]) Describe() string {
+	if len(p.names) == 0 {
+		return "(empty)"
+	}
+	return strings.Join(p.names, " -> ")
+}
+
+// Then appends an operator that may change the element type, under the
+// given name. Any function of a stream fits, including the package's own
+// operators and other pipelines' Apply.
+func Then[T, R, U any](p Pipeline[T, R], name string, op func(Stream[R]) Stream[U]) Pipeline[T, U] {
+	return Pipeline[T, U]{
+		names: append(p.names[:len(p.names):len(p.names)], name),
+		apply: func(s Stream[T]) Stream[U] {
+			return Stage(p.apply(s), name, op)
+		},
+	}
+}
+
+// Compose chains two pipelines, a first.
+func Compose[T, R, U any](a Pipeline[T, R], b Pipeline[R, U]) Pipeline[T, U] {
+	return Pipeline[T, U]{
+		names: append(a.names[:len(a.names):len(a.names)], b.names...),
+		apply: func(s Stream[T]) Stream[U] {
+			return b.apply(a.apply(s))
+		},
+	}
+}
+
+// PipeMap appends a Map stage.
+func PipeMap[T, R, U any](p Pipeline[T, R], fn func(R) U) Pipeline[T, U] {
+	return Then(p, "Map", func(s Stream[R]) Stream[U] { return Map(s, fn) })
+}
+
+// PipeFlatMap appends a FlatMap stage.
+func PipeFlatMap[T, R, U any](p Pipeline[T, R], fn func(R) iter.Seq[U]) Pipeline[T, U] {
+	return Then(p, "FlatMap", func(s Stream[R]) Stream[U] { return FlatMap(s, fn) })
+}
+
+// Filter appends a Filter stage.
+func (p Pipeline[T, R]) Filter(fn func(R) bool) Pipeline[T, R] {
+	return Then(p, "Filter", func(s Stream[R]) Stream[R] { return s.Filter(fn) })
+}
+
+// MapErr appends a MapErr stage.
+func (p Pipeline[T, R]) MapErr(fn func(R) (R, error)) Pipeline[T, R] {
+	return Then(p, "MapErr", func(s Stream[R]) Stream[R] { return MapErr(s, fn) })
+}
+
+// Take appends a Take stage.
+func (p Pipeline[T, R]) Take(n int) Pipeline[T, R] {
+	return Then(p, fmt.Sprintf("Take(%d)", n), func(s Stream[R]) Stream[R] { return s.Take(n) })
+}
+
+// Skip appends a Skip stage.
+func (p Pipeline[T, R]) Skip(n int) Pipeline[T, R] {
+	return Then(p, fmt.Sprintf("Skip(%d)", n), func(s Stream[R]) Stream[R] { return s.Skip(n) })
+}
+
+// TakeWhile appends a TakeWhile stage.
+func (p Pipeline[T, R]) TakeWhile(fn func(R) bool) Pipeline[T, R] {
+	return Then(p, "TakeWhile", func(s Stream[R]) Stream[R] { return s.TakeWhile(fn) })
+}
+
+// DropWhile appends a DropWhile stage.
+func (p Pipeline[T, R]) DropWhile(fn func(R) bool) Pipeline[T, R] {
+	return Then(p, "DropWhile", func(s Stream[R]) Stream[R] { return s.DropWhile(fn) })
+}
+
+// Peek appends a Peek stage.
+func (p Pipeline[T, R]) Peek(fn func(R)) Pipeline[T, R] {
+	return Then(p, "Peek", func(s Stream[R]) Stream[R] { return s.Peek(fn) })
+}
@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+	labels := PipeMap(NewPipeline[int]().Filter(isEven), strconv.Itoa).Take(2)
+
+	t.Run("Applies To Many Sources", func(t *testing.T) {
+		a, err := labels.Apply(FromSlice([]int{1, 2, 3, 4, 5, 6})).Collect()
+		if err != nil || !slices.Equal(a, []string{"2", "4"}) {
+			t.Errorf("expected [2 4], got %v (err: %v)", a, err)
+		}
+		b, err := labels.Apply(FromSlice([]int{10, 11, 12})).Collect()
+		if err != nil || !slices.Equal(b, []string{"10", "12"}) {
+			t.Errorf("expected [10 12], got %v (err: %v)", b, err)
+		}
+	})
+
+	t.Run("Describe", func(t *testing.T) {
+		if got := labels.Describe(); got != "Filter -> Map -> Take(2)" {
+			t.Errorf("unexpected description %q", got)
+		}
+		if got := NewPipeline[int]().Describe(); got != "(empty)" {
+			t.Errorf("unexpected description %q", got)
+		}
+	})
+
+	t.Run("Steps Do Not Share State", func(t *testing.T) {
+		base := NewPipeline[int]().Filter(isEven)
+		a := base.Take(1)
+		b := base.Skip(1)
+		if a.Describe() != "Filter -> Take(1)" || b.Describe() != "Filter -> Skip(1)" {
+			t.Errorf("branches leaked into each other: %q, %q", a.Describe(), b.Describe())
+		}
+	})
+
+	t.Run("Compose And Then", func(t *testing.T) {
+		parse := NewPipeline[string]().MapErr(func(s string) (string, error) {
+			if _, err := strconv.Atoi(s); err != nil {
+				return "", err
+			}
+			return s, nil
+		})
+		lengths := Then(NewPipeline[string](), "Len", func(s Stream[string]) Stream[int] {
+			return Map(s, func(s string) int { return len(s) })
+		})
+		p := Compose(parse, lengths)
+
+		if got := p.Describe(); got != "MapErr -> Len" {
+			t.Errorf("unexpected description %q", got)
+		}
+		got, err := p.Apply(FromSlice([]string{"1", "22"})).Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("expected [1 2], got %v (err: %v)", got, err)
+		}
+
+		_, err = p.Apply(FromSlice([]string{"1", "x"})).Collect()
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) {
+			t.Errorf("expected a parse error, got %v", err)
+		}
+	})
+
+	t.Run("Stages Are Measured On Instrumented Sources", func(t *testing.T) {
+		out := labels.Apply(FromSlice([]int{1, 2, 3, 4}).Instrument(nil))
+		if _, err := out.Collect(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var names []string
+		for _, m := range out.Metrics() {
+			names = append(names, m.Name)
+		}
+		if !slices.Equal(names, []string{"Filter", "Map", "Take(2)"}) {
+			t.Errorf("unexpected stages %v", names)
+		}
+	})
+}
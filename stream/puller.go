@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// puller runs a source once on behalf of several consumers, such as the runs
+// of a Cache or the branches of a Tee, one item at a time. Every field is
+// guarded by the owner's mutex, which pull releases while the source runs.
+type puller[T any] struct {
+	mu   *sync.Mutex
+	cond *sync.Cond
+
+	src  Stream[T]
+	errp *error // the source run's live wire
+	next func() (T, bool)
+	stop func()
+
+	pulling bool  // a consumer is pulling; the others wait on cond
+	done    bool  // source exhausted, failed or panicked
+	err     error // the source's error, or errSourcePanic
+}
+
+func newPuller[T any](src Stream[T], mu *sync.Mutex, cond *sync.Cond) *puller[T] {
+	return &puller[T]{src: src, mu: mu, cond: cond}
+}
+
+// pull fetches one item from the source without holding the lock.
+// Callers must hold the lock, with neither pulling nor done set; it is
+// released during the call. On success pulling is left set, so the caller can
+// hand the item around before calling release.
+func (p *puller[T]) pull() (v T, ok bool) {
+	if p.next == nil {
+		var seq iter.Seq[T]
+		seq, p.errp = p.src.open()
+		p.next, p.stop = iter.Pull(seq)
+		// An abandoned, partially read source would otherwise keep its
+		// coroutine alive forever. stop runs user code such as OnClose, so
+		// keep it off the runtime's cleanup goroutine.
+		runtime.AddCleanup(p, func(stop func()) { go stop() }, p.stop)
+	}
+	p.pulling = true
+	p.mu.Unlock()
+
+	returned := false
+	defer func() {
+		p.mu.Lock()
+		if *p.errp != nil {
+			ok = false
+		}
+		if !ok {
+			// exhausted, failed or panicked: no one may pull again
+			p.done = true
+			p.err = *p.errp
+			if !returned {
+				p.err = errSourcePanic
+			}
+			p.stop()
+			p.release()
+		}
+	}()
+
+	v, ok = p.next()
+	returned = true
+	return v, ok
+}
+
+// release lets the next consumer pull. Callers must hold the lock.
+func (p *puller[T]) release() {
+	p.pulling = false
+	p.cond.Broadcast()
+}
+
+// close stops the source early. Callers must hold the lock.
+func (p *puller[T]) close() {
+	if p.stop != nil {
+		p.stop()
+	}
+}
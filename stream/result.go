@@ -0,0 +1,99 @@
+package stream
+
+import (
+	"errors"
+	"iter"
+)
+
+// Result holds either a value or the error that prevented producing it.
+// Streams of Results keep failures inline instead of stopping at the first
+// one: a middle ground between Map, which can't fail, and MapErr, which stops.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Ok returns a successful Result.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{Value: v}
+}
+
+// Failed returns a failed Result.
+func Failed[T any](err error) Result[T] {
+	return Result[T]{Err: err}
+}
+
+// IsOk reports whether r holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.Err == nil
+}
+
+// Get returns the value and error, in the usual Go order.
+func (r Result[T]) Get() (T, error) {
+	return r.Value, r.Err
+}
+
+// MapResult applies fn to every element and keeps the outcome, value or error,
+// as a Result. Unlike MapErr a failure doesn't stop the stream.
+func MapResult[T, R any](s Stream[T], fn func(T) (R, error)) Stream[Result[R]] {
+	return Map(s, func(v T) Result[R] {
+		r, err := fn(v)
+		return Result[R]{Value: r, Err: err}
+	})
+}
+
+// SplitResults separates successes from failures, like Partition.
+// The same buffering rules apply: the two streams may be consumed one after
+// the other or concurrently.
+func SplitResults[T any](s Stream[Result[T]]) (Stream[T], Stream[error]) {
+	oks, fails := Partition(s, Result[T].IsOk)
+	values := Map(oks, func(r Result[T]) T { return r.Value })
+	errs := Map(fails, func(r Result[T]) error { return r.Err })
+	return values, errs
+}
+
+// Unwrap turns a stream of Results back into an all-or-nothing stream:
+// it yields the values and trips the live wire at the first failure.
+func Unwrap[T any](s Stream[Result[T]]) Stream[T] {
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				for r := range seq {
+					if r.Err != nil {
+						*errp = r.Err
+						return
+					}
+					if !yield(r.Value) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// CollectResults drains the stream and returns every successful value along
+// with all the failures joined into one error, or nil if there were none.
+// A stream error, as opposed to a per-element failure, is joined in too.
+func CollectResults[T any](s Stream[Result[T]]) ([]T, error) {
+	seq, errp := s.open()
+	var values []T
+	var errs []error
+	for r := range seq {
+		if *errp != nil {
+			break
+		}
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+			continue
+		}
+		values = append(values, r.Value)
+	}
+	if *errp != nil {
+		errs = append(errs, *errp)
+	}
+	return values, errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func parseAll(in ...string) Stream[Result[int]] {
+	return MapResult(FromSlice(in), strconv.Atoi)
+}
+
+func TestMapResult(t *testing.T) {
+	results, err := parseAll("1", "x", "3").Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected every element to be kept, got %v", results)
+	}
+	if !results[0].IsOk() || results[0].Value != 1 {
+		t.Errorf("expected Ok(1), got %v", results[0])
+	}
+	if v, err := results[1].Get(); err == nil {
+		t.Errorf("expected a failure, got %v", v)
+	}
+}
+
+func TestSplitResults(t *testing.T) {
+	values, errs := SplitResults(parseAll("1", "x", "3", "y"))
+
+	got, err := values.Collect()
+	if err != nil || !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("expected [1 3], got %v (err: %v)", got, err)
+	}
+	n, err := errs.Count()
+	if err != nil || n != 2 {
+		t.Errorf("expected 2 failures, got %d (err: %v)", n, err)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	got, err := Unwrap(parseAll("1", "2")).Collect()
+	if err != nil || !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("expected [1 2], got %v (err: %v)", got, err)
+	}
+
+	var seen []int
+	err = Unwrap(parseAll("1", "x", "3")).ForEach(func(n int) { seen = append(seen, n) })
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) || numErr.Num != "x" {
+		t.Errorf("expected the parse error for x, got %v", err)
+	}
+	if !slices.Equal(seen, []int{1}) {
+		t.Errorf("expected Unwrap to stop at the failure, got %v", seen)
+	}
+}
+
+func TestCollectResults(t *testing.T) {
+	t.Run("Successes And Joined Failures", func(t *testing.T) {
+		got, err := CollectResults(parseAll("1", "x", "3", "y"))
+		if !slices.Equal(got, []int{1, 3}) {
+			t.Errorf("expected [1 3], got %v", got)
+		}
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) {
+			t.Fatalf("expected parse errors, got %v", err)
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 2 {
+			t.Errorf("expected 2 joined errors, got %v", err)
+		}
+	})
+
+	t.Run("No Failures", func(t *testing.T) {
+		got, err := CollectResults(parseAll("1", "2"))
+		if err != nil || !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("expected [1 2], got %v (err: %v)", got, err)
+		}
+	})
+
+	t.Run("Stream Error Is Joined In", func(t *testing.T) {
+		boom := errors.New("boom")
+		s := MapErr(parseAll("x", "2", "3"), func(r Result[int]) (Result[int], error) {
+			if r.Value == 3 {
+				return r, boom
+			}
+			return r, nil
+		})
+		got, err := CollectResults(s)
+		if !slices.Equal(got, []int{2}) {
+			t.Errorf("expected [2], got %v", got)
+		}
+		var numErr *strconv.NumError
+		if !errors.Is(err, boom) || !errors.As(err, &numErr) {
+			t.Errorf("expected both the failure and boom, got %v", err)
+		}
+	})
+}
@@ -6,9 +6,16 @@ import (
 	"slices"
 )
 
+// Stream is a lazy, re-runnable sequence with error tracking.
+//
+// Each terminal operation builds a fresh run of the pipeline with its own
+// error slot, so a stream value may be consumed several times, even
+// concurrently, without one run's error leaking into the next. Streams built
+// with New and a caller-owned error pointer keep sharing that pointer.
 type Stream[T any] struct {
-	seq iter.Seq[T]
-	err *error
+	run     func(errp *error) iter.Seq[T]
+	err     *error   // caller-owned error slot, nil means a fresh slot per run
+	metrics *Metrics // nil unless Instrument was called upstream
 }
 
 type Entry[K, V any] struct {
@@ -19,46 +26,47 @@ type Entry[K, V any] struct {
 // constructors
 
 // New wraps a standard Go 1.23 iterator into a Basin Stream.
-// It requires a pointer to an error so the stream remains "Error-Aware."
+// If errPtr is non-nil, the producer may report failures through it and every
+// run of the stream shares it, as before. If errPtr is nil, each run gets its own slot.
 func New[T any](seq iter.Seq[T], errPtr *error) Stream[T] {
-	if errPtr == nil {
-		// Safety: If the user doesn't provide a pointer,
-		// we give them a local one so the stream doesn't crash.
-		var err error
-		errPtr = &err
-	}
 	return Stream[T]{
-		seq: seq,
+		run: func(*error) iter.Seq[T] { return seq },
 		err: errPtr,
 	}
 }
 
 func FromSeq[T any](seq iter.Seq[T]) Stream[T] {
-	// err is instantiated here
-	var err error
-	return Stream[T]{seq: seq, err: &err}
+	return New(seq, nil)
 }
 
 // Seq returns the raw Go 1.23 iterator for use in for-range loops.
+// Errors raised by operators during the loop are not reported; use a terminal for that.
 func (s Stream[T]) Seq() iter.Seq[T] {
-	return s.seq
+	seq, _ := s.open()
+	return seq
+}
+
+// open starts a new run of the pipeline. It returns the run's iterator and
+// the error slot its operators and terminal share.
+func (s Stream[T]) open() (iter.Seq[T], *error) {
+	errp := s.err
+	if errp == nil {
+		errp = new(error)
+	}
+	return s.run(errp), errp
 }
 
 // FromSlice creates a Stream from a standard Go slice.
 // Since slices are in-memory, the error pointer will stay nil
 // unless a later operation (like MapErr) trips it.
 func FromSlice[T any](items []T) Stream[T] {
-	var err error
-	return Stream[T]{
-		err: &err,
-		seq: func(yield func(T) bool) {
-			for _, v := range items {
-				if !yield(v) {
-					return
-				}
+	return FromSeq(func(yield func(T) bool) {
+		for _, v := range items {
+			if !yield(v) {
+				return
 			}
-		},
-	}
+		}
+	})
 }
 
 // Filtering functions Filter, Take, Skip
@@ -66,111 +74,236 @@ func FromSlice[T any](items []T) Stream[T] {
 // Filter creates a lazy iterator that only yields matching items.
 func (s Stream[T]) Filter(fn func(T) bool) Stream[T] {
 	return Stream[T]{
-		err: s.err,
-		seq: func(yield func(T) bool) {
-			for v := range s.seq {
-				if fn(v) {
-					if !yield(v) {
-						return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				for v := range seq {
+					if fn(v) {
+						if !yield(v) {
+							return
+						}
 					}
 				}
 			}
-		}}
+		},
+	}
 }
 
 // Take limits the number of items yielded.
 func (s Stream[T]) Take(n int) Stream[T] {
 	return Stream[T]{
-		err: s.err,
-		seq: func(yield func(T) bool) {
-			count := 0
-			for v := range s.seq {
-				if count >= n || !yield(v) {
-					return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				count := 0
+				for v := range seq {
+					if count >= n || !yield(v) {
+						return
+					}
+					count++
 				}
-				count++
 			}
-		}}
+		},
+	}
 }
 
 func (s Stream[T]) Skip(n int) Stream[T] {
 	return Stream[T]{
-		err: s.err,
-		seq: func(yield func(T) bool) {
-			skipped := 0
-			for v := range s.seq {
-				// exit early if there's an error
-				if s.err != nil && *s.err != nil {
-					return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				skipped := 0
+				for v := range seq {
+					// exit early if there's an error
+					if *errp != nil {
+						return
+					}
+
+					if skipped < n {
+						skipped++
+						continue
+					}
+
+					if !yield(v) {
+						return
+					}
 				}
+			}
+		},
+	}
+}
 
-				if skipped < n {
-					skipped++
-					continue
+// TakeWhile yields items while fn returns true and stops at the first item that fails.
+func (s Stream[T]) TakeWhile(fn func(T) bool) Stream[T] {
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+					if !fn(v) || !yield(v) {
+						return
+					}
 				}
+			}
+		},
+	}
+}
 
-				if !yield(v) {
-					return
+// DropWhile skips items while fn returns true, then yields the rest unconditionally.
+func (s Stream[T]) DropWhile(fn func(T) bool) Stream[T] {
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				dropping := true
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+					if dropping && fn(v) {
+						continue
+					}
+					dropping = false
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Peek calls fn on each item as it flows past, without changing the stream.
+// Useful for logging or debugging; fn only runs for items that are pulled downstream.
+func (s Stream[T]) Peek(fn func(T)) Stream[T] {
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+					fn(v)
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Enumerate pairs each item with its zero-based index.
+func (s Stream[T]) Enumerate() Stream2[int, T] {
+	return Stream2[int, T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[int, T] {
+			seq := s.run(errp)
+			return func(yield func(int, T) bool) {
+				i := 0
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+					if !yield(i, v) {
+						return
+					}
+					i++
 				}
 			}
-		}}
+		},
+	}
 }
 
 // Short circuting functions First, Any, All
+// These break out of the loop rather than returning from inside it, so the
+// iterator finishes (and runs any OnClose cleanups) before the error is checked.
 
 // First returns the first element of the stream.
 func (s Stream[T]) First() (T, error) {
-	var zero T
-	// range s.seq will execute the iterator
-	for v := range s.seq {
-		// Even for the first item, we check if the source failed
-		if s.err != nil && *s.err != nil {
-			return zero, *s.err
-		}
-		return v, nil
+	seq, errp := s.open()
+	var zero, first T
+	// range seq will execute the iterator
+	for v := range seq {
+		first = v
+		break
+	}
+	// Even for the first item, we check if the source failed
+	if err := *errp; err != nil {
+		return zero, err
 	}
-	return zero, s.check()
+	return first, nil
 }
 
 // Any returns true if any element of the stream matches the predicate.
 func (s Stream[T]) Any(fn func(T) bool) (bool, error) {
-	for v := range s.seq {
-		if s.err != nil && *s.err != nil {
-			return false, *s.err
+	seq, errp := s.open()
+	found := false
+	for v := range seq {
+		if *errp != nil {
+			break
 		}
 		if fn(v) {
 			// short circuit and return true
-			return true, nil
+			found = true
+			break
 		}
 	}
-	return false, s.check()
+	if err := *errp; err != nil {
+		return false, err
+	}
+	return found, nil
 }
 
 // All returns true if all elements of the stream match the predicate.
 // note if the stream is empty, All returns true.
 func (s Stream[T]) All(fn func(T) bool) (bool, error) {
-	for v := range s.seq {
-		if s.err != nil && *s.err != nil {
-			return false, *s.err
+	seq, errp := s.open()
+	all := true
+	for v := range seq {
+		if *errp != nil {
+			break
 		}
 		if !fn(v) {
-			return false, nil
+			all = false
+			break
 		}
 	}
-	return true, s.check()
+	if err := *errp; err != nil {
+		return false, err
+	}
+	return all, nil
 }
 
 // Terminal functions Collect, Count, and ForEach
 
 // Count counts the number of items in the stream.
 func (s Stream[T]) Count() (int, error) {
+	seq, errp := s.open()
 	n := 0
-	for range s.seq {
+	for range seq {
 		n++
 	}
 
-	if s.err != nil && *s.err != nil {
-		return 0, *s.err
+	if *errp != nil {
+		return 0, *errp
 	}
 
 	return n, nil
@@ -178,35 +311,38 @@ func (s Stream[T]) Count() (int, error) {
 
 // Collect gathers all items into a slice and returns any error encountered.
 func (s Stream[T]) Collect() ([]T, error) {
-	items := slices.Collect(s.seq)
+	seq, errp := s.open()
+	items := slices.Collect(seq)
 
-	// the s.err is not potentially set until after Collect is called
-	// so check s.err != nil after
-	if s.err != nil && *s.err != nil {
-		return nil, *s.err
+	// the error slot is not potentially set until after Collect is called
+	// so check it after
+	if *errp != nil {
+		return nil, *errp
 	}
 
 	return items, nil
 }
 
 func (s Stream[T]) ForEach(fn func(T)) error {
-	for v := range s.seq {
+	seq, errp := s.open()
+	for v := range seq {
 		// If an error was tripped by a previous MapErr or the source
-		if s.err != nil && *s.err != nil {
-			return *s.err
+		if *errp != nil {
+			break
 		}
 		fn(v)
 	}
-	return s.check()
+	return *errp
 }
 
 func (s Stream[T]) Reduce(fn func(T, T) T) (T, error) {
+	seq, errp := s.open()
 	var acc T
 	var first = true
 
-	for v := range s.seq {
-		if s.err != nil && *s.err != nil {
-			return acc, *s.err
+	for v := range seq {
+		if *errp != nil {
+			break
 		}
 		if first {
 			acc = v
@@ -216,16 +352,11 @@ func (s Stream[T]) Reduce(fn func(T, T) T) (T, error) {
 		acc = fn(acc, v)
 	}
 
+	if err := *errp; err != nil {
+		return acc, err
+	}
 	if first {
 		return acc, fmt.Errorf("cannot reduce empty stream")
 	}
 	return acc, nil
 }
-
-// check is a private helper to keep things DRY
-func (s Stream[T]) check() error {
-	if s.err != nil && *s.err != nil {
-		return *s.err
-	}
-	return nil
-}
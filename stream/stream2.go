@@ -6,9 +6,12 @@ import (
 )
 
 // Stream2 represents a sequence of Key-Value pairs with a "Live Wire" error pointer.
+// Like Stream, each terminal operation runs the pipeline with a fresh error slot
+// unless the stream was built with a caller-owned pointer.
 type Stream2[K, V any] struct {
-	seq iter.Seq2[K, V]
-	err *error
+	run     func(errp *error) iter.Seq2[K, V]
+	err     *error   // caller-owned error slot, nil means a fresh slot per run
+	metrics *Metrics // nil unless Instrument was called upstream
 }
 
 // Pair is a simple container for when users want to collect Stream2 into a slice.
@@ -20,36 +23,43 @@ type Pair[K, V any] struct {
 // --- Constructors ---
 
 // New2 wraps a standard Go 1.23 K-V iterator into a Basin Stream2.
+// As with New, a nil errPtr gives each run its own error slot.
 func New2[K, V any](seq iter.Seq2[K, V], errPtr *error) Stream2[K, V] {
-	if errPtr == nil {
-		var err error
-		errPtr = &err
-	}
 	return Stream2[K, V]{
-		seq: seq,
+		run: func(*error) iter.Seq2[K, V] { return seq },
 		err: errPtr,
 	}
 }
 
 func FromSeq2[K, V any](seq iter.Seq2[K, V]) Stream2[K, V] {
-	// err is instantiated here
-	var err error
-	return Stream2[K, V]{seq: seq, err: &err}
+	return New2(seq, nil)
+}
+
+// Seq returns the raw Go 1.23 iterator for use in for-range loops.
+// Errors raised by operators during the loop are not reported; use a terminal for that.
+func (s Stream2[K, V]) Seq() iter.Seq2[K, V] {
+	seq, _ := s.open()
+	return seq
+}
+
+// open starts a new run of the pipeline, see Stream.open.
+func (s Stream2[K, V]) open() (iter.Seq2[K, V], *error) {
+	errp := s.err
+	if errp == nil {
+		errp = new(error)
+	}
+	return s.run(errp), errp
 }
 
 // FromMap creates a Stream2 from a standard Go map.
 func FromMap[K comparable, V any](m map[K]V) Stream2[K, V] {
-	var err error
-	return Stream2[K, V]{
-		err: &err,
-		seq: func(yield func(K, V) bool) {
-			for k, v := range m {
-				if !yield(k, v) {
-					return
-				}
+	return FromSeq2(func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
 			}
-		},
-	}
+		}
+	})
 }
 
 // --- Bridges (Stream2 -> Stream) ---
@@ -57,11 +67,15 @@ func FromMap[K comparable, V any](m map[K]V) Stream2[K, V] {
 // Keys returns a Stream containing only the keys.
 func (s Stream2[K, V]) Keys() Stream[K] {
 	return Stream[K]{
-		err: s.err,
-		seq: func(yield func(K) bool) {
-			for k := range s.seq {
-				if !yield(k) {
-					return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[K] {
+			seq := s.run(errp)
+			return func(yield func(K) bool) {
+				for k := range seq {
+					if !yield(k) {
+						return
+					}
 				}
 			}
 		},
@@ -71,11 +85,53 @@ func (s Stream2[K, V]) Keys() Stream[K] {
 // Values returns a Stream containing only the values.
 func (s Stream2[K, V]) Values() Stream[V] {
 	return Stream[V]{
-		err: s.err,
-		seq: func(yield func(V) bool) {
-			for _, v := range s.seq {
-				if !yield(v) {
-					return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[V] {
+			seq := s.run(errp)
+			return func(yield func(V) bool) {
+				for _, v := range seq {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Pairs converts a Stream2 into a Stream of Pair structs.
+// It is a function rather than a method because Stream.Enumerate already
+// maps Stream into Stream2, and Go rejects the resulting instantiation cycle.
+func Pairs[K, V any](s Stream2[K, V]) Stream[Pair[K, V]] {
+	return Stream[Pair[K, V]]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[Pair[K, V]] {
+			seq := s.run(errp)
+			return func(yield func(Pair[K, V]) bool) {
+				for k, v := range seq {
+					if !yield(Pair[K, V]{Key: k, Value: v}) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Swap returns a Stream2 with keys and values exchanged.
+func (s Stream2[K, V]) Swap() Stream2[V, K] {
+	return Stream2[V, K]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[V, K] {
+			seq := s.run(errp)
+			return func(yield func(V, K) bool) {
+				for k, v := range seq {
+					if !yield(v, k) {
+						return
+					}
 				}
 			}
 		},
@@ -86,12 +142,16 @@ func (s Stream2[K, V]) Values() Stream[V] {
 
 func (s Stream2[K, V]) Filter(fn func(K, V) bool) Stream2[K, V] {
 	return Stream2[K, V]{
-		err: s.err,
-		seq: func(yield func(K, V) bool) {
-			for k, v := range s.seq {
-				if fn(k, v) {
-					if !yield(k, v) {
-						return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[K, V] {
+			seq := s.run(errp)
+			return func(yield func(K, V) bool) {
+				for k, v := range seq {
+					if fn(k, v) {
+						if !yield(k, v) {
+							return
+						}
 					}
 				}
 			}
@@ -101,14 +161,46 @@ func (s Stream2[K, V]) Filter(fn func(K, V) bool) Stream2[K, V] {
 
 func (s Stream2[K, V]) Take(n int) Stream2[K, V] {
 	return Stream2[K, V]{
-		err: s.err,
-		seq: func(yield func(K, V) bool) {
-			count := 0
-			for k, v := range s.seq {
-				if count >= n || !yield(k, v) {
-					return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[K, V] {
+			seq := s.run(errp)
+			return func(yield func(K, V) bool) {
+				count := 0
+				for k, v := range seq {
+					if count >= n || !yield(k, v) {
+						return
+					}
+					count++
+				}
+			}
+		},
+	}
+}
+
+func (s Stream2[K, V]) Skip(n int) Stream2[K, V] {
+	return Stream2[K, V]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[K, V] {
+			seq := s.run(errp)
+			return func(yield func(K, V) bool) {
+				skipped := 0
+				for k, v := range seq {
+					// exit early if there's an error
+					if *errp != nil {
+						return
+					}
+
+					if skipped < n {
+						skipped++
+						continue
+					}
+
+					if !yield(k, v) {
+						return
+					}
 				}
-				count++
 			}
 		},
 	}
@@ -119,11 +211,33 @@ func (s Stream2[K, V]) Take(n int) Stream2[K, V] {
 // MapValues transforms the values (V -> R) while keeping the keys the same.
 func MapValues[K, V, R any](s Stream2[K, V], fn func(V) R) Stream2[K, R] {
 	return Stream2[K, R]{
-		err: s.err,
-		seq: func(yield func(K, R) bool) {
-			for k, v := range s.seq {
-				if !yield(k, fn(v)) {
-					return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[K, R] {
+			seq := s.run(errp)
+			return func(yield func(K, R) bool) {
+				for k, v := range seq {
+					if !yield(k, fn(v)) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// MapKeys transforms the keys (K -> R) while keeping the values the same.
+func MapKeys[K, V, R any](s Stream2[K, V], fn func(K) R) Stream2[R, V] {
+	return Stream2[R, V]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[R, V] {
+			seq := s.run(errp)
+			return func(yield func(R, V) bool) {
+				for k, v := range seq {
+					if !yield(fn(k), v) {
+						return
+					}
 				}
 			}
 		},
@@ -134,59 +248,134 @@ func MapValues[K, V, R any](s Stream2[K, V], fn func(V) R) Stream2[K, R] {
 // If fn returns an error, the "Live Wire" trips and the stream stops.
 func MapErr2[K, V, NK, NV any](s Stream2[K, V], fn func(K, V) (NK, NV, error)) Stream2[NK, NV] {
 	return Stream2[NK, NV]{
-		err: s.err,
-		seq: func(yield func(NK, NV) bool) {
-			for k, v := range s.seq {
-				nk, nv, err := fn(k, v)
-				if err != nil {
-					*s.err = err
-					return
-				}
-				if !yield(nk, nv) {
-					return
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[NK, NV] {
+			seq := s.run(errp)
+			return func(yield func(NK, NV) bool) {
+				for k, v := range seq {
+					nk, nv, err := fn(k, v)
+					if err != nil {
+						*errp = err
+						return
+					}
+					if !yield(nk, nv) {
+						return
+					}
 				}
 			}
 		},
 	}
 }
 
+// --- Short Circuiting Functions ---
+
+// First returns the first key-value pair of the stream.
+func (s Stream2[K, V]) First() (K, V, error) {
+	seq, errp := s.open()
+	var zeroK, firstK K
+	var zeroV, firstV V
+	for k, v := range seq {
+		firstK, firstV = k, v
+		break
+	}
+	if err := *errp; err != nil {
+		return zeroK, zeroV, err
+	}
+	return firstK, firstV, nil
+}
+
+// Any returns true if any pair of the stream matches the predicate.
+func (s Stream2[K, V]) Any(fn func(K, V) bool) (bool, error) {
+	seq, errp := s.open()
+	found := false
+	for k, v := range seq {
+		if *errp != nil {
+			break
+		}
+		if fn(k, v) {
+			found = true
+			break
+		}
+	}
+	if err := *errp; err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// All returns true if all pairs of the stream match the predicate.
+// note if the stream is empty, All returns true.
+func (s Stream2[K, V]) All(fn func(K, V) bool) (bool, error) {
+	seq, errp := s.open()
+	all := true
+	for k, v := range seq {
+		if *errp != nil {
+			break
+		}
+		if !fn(k, v) {
+			all = false
+			break
+		}
+	}
+	if err := *errp; err != nil {
+		return false, err
+	}
+	return all, nil
+}
+
 // --- Terminal Functions ---
 
 func (s Stream2[K, V]) Collect() ([]Pair[K, V], error) {
+	seq, errp := s.open()
 	var results []Pair[K, V]
-	for k, v := range s.seq {
+	for k, v := range seq {
 		results = append(results, Pair[K, V]{Key: k, Value: v})
 	}
 
-	if s.err != nil && *s.err != nil {
-		return nil, *s.err
+	if *errp != nil {
+		return nil, *errp
 	}
 
 	return results, nil
 }
 
 func (s Stream2[K, V]) Count() (int, error) {
+	seq, errp := s.open()
 	n := 0
-	for range s.seq {
+	for range seq {
 		n++
 	}
-	if s.err != nil && *s.err != nil {
-		return 0, *s.err
+	if *errp != nil {
+		return 0, *errp
 	}
 	return n, nil
 }
 
+func (s Stream2[K, V]) ForEach(fn func(K, V)) error {
+	seq, errp := s.open()
+	for k, v := range seq {
+		// If an error was tripped by a previous MapErr2 or the source
+		if *errp != nil {
+			break
+		}
+		fn(k, v)
+	}
+	return *errp
+}
+
 // Reduce collapses the Stream2 into a single [K, V] pair.
 // It uses the first pair as the initial accumulator.
 func (s Stream2[K, V]) Reduce(fn func(k1 K, v1 V, k2 K, v2 V) (K, V)) (K, V, error) {
+	seq, errp := s.open()
 	var accK K
 	var accV V
 	var first = true
 
-	for k, v := range s.seq {
+	for k, v := range seq {
 		// Respect the shared error pointer from the source or MapErr
-		if s.err != nil && *s.err != nil {
-			return accK, accV, *s.err
+		if *errp != nil {
+			break
 		}
 
 		if first {
@@ -200,17 +389,12 @@ func (s Stream2[K, V]) Reduce(fn func(k1 K, v1 V, k2 K, v2 V) (K, V)) (K, V, err
 		accK, accV = fn(accK, accV, k, v)
 	}
 
+	if err := *errp; err != nil {
+		return accK, accV, err
+	}
 	if first {
 		return accK, accV, fmt.Errorf("cannot reduce empty stream")
 	}
 
 	return accK, accV, nil
 }
-
-// check is a private helper to keep things DRY
-func (s Stream2[K, V]) check() error {
-	if s.err != nil && *s.err != nil {
-		return *s.err
-	}
-	return nil
-}
@@ -10,17 +10,13 @@ import (
 
 // Helper to turn a map into a Stream2 for testing
 func streamFromMap[K comparable, V any](m map[K]V) Stream2[K, V] {
-	var err error
-	return Stream2[K, V]{
-		err: &err,
-		seq: func(yield func(K, V) bool) {
-			for k, v := range m {
-				if !yield(k, v) {
-					return
-				}
+	return FromSeq2(func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
 			}
-		},
-	}
+		}
+	})
 }
 
 func TestMap2(t *testing.T) {
@@ -33,7 +29,7 @@ func TestMap2(t *testing.T) {
 	})
 
 	results := make(map[int]string)
-	for k, v := range mapped.seq {
+	for k, v := range mapped.Seq() {
 		results[k] = v
 	}
 
@@ -47,18 +43,15 @@ func TestMap2(t *testing.T) {
 func TestMap2Err(t *testing.T) {
 	t.Run("Success Path", func(t *testing.T) {
 		var errPtr error
-		s := Stream2[string, int]{
-			err: &errPtr,
-			seq: func(yield func(string, int) bool) {
-				yield("a", 1)
-			},
-		}
+		s := New2(func(yield func(string, int) bool) {
+			yield("a", 1)
+		}, &errPtr)
 
 		mapped := Map2Err(s, func(k string, v int) (string, int, error) {
 			return k + "!", v * 10, nil
 		})
 
-		for k, v := range mapped.seq {
+		for k, v := range mapped.Seq() {
 			if k != "a!" || v != 10 {
 				t.Errorf("Unexpected values: %s, %d", k, v)
 			}
@@ -70,16 +63,13 @@ func TestMap2Err(t *testing.T) {
 
 	t.Run("Error Path", func(t *testing.T) {
 		var errPtr error
-		s := Stream2[string, int]{
-			err: &errPtr,
-			seq: func(yield func(string, int) bool) {
-				// Check the boolean! If Map2Err says "stop" (false), we must stop.
-				if !yield("a", 1) {
-					return
-				}
-				yield("b", 2)
-			},
-		}
+		s := New2(func(yield func(string, int) bool) {
+			// Check the boolean! If Map2Err says "stop" (false), we must stop.
+			if !yield("a", 1) {
+				return
+			}
+			yield("b", 2)
+		}, &errPtr)
 
 		sentinelErr := errors.New("boom")
 		mapped := Map2Err(s, func(k string, v int) (string, int, error) {
@@ -90,7 +80,7 @@ func TestMap2Err(t *testing.T) {
 		})
 
 		// Trigger execution
-		for range mapped.seq {
+		for range mapped.Seq() {
 		}
 
 		if *mapped.err != sentinelErr {
@@ -101,12 +91,9 @@ func TestMap2Err(t *testing.T) {
 
 func TestFlatMap2(t *testing.T) {
 	var errPtr error
-	s := Stream2[string, int]{
-		err: &errPtr,
-		seq: func(yield func(string, int) bool) {
-			yield("numbers", 2)
-		},
-	}
+	s := New2(func(yield func(string, int) bool) {
+		yield("numbers", 2)
+	}, &errPtr)
 
 	// FlatMap transforms 1 entry into 2 entries
 	flat := FlatMap2(s, func(k string, v int) iter.Seq2[string, int] {
@@ -120,7 +107,7 @@ func TestFlatMap2(t *testing.T) {
 	})
 
 	var results []string
-	for k, _ := range flat.seq {
+	for k, _ := range flat.Seq() {
 		results = append(results, k)
 	}
 
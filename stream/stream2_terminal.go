@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Last consumes the stream and returns the very last key-value pair.
+// Returns an error if the stream is empty or if an upstream error occurs.
+func (s Stream2[K, V]) Last() (K, V, error) {
+	var lastK K
+	var lastV V
+	var found bool
+
+	err := s.ForEach(func(k K, v V) {
+		lastK, lastV = k, v
+		found = true
+	})
+
+	if err != nil {
+		return lastK, lastV, err
+	}
+	if !found {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, fmt.Errorf("cannot get last element of empty stream")
+	}
+	return lastK, lastV, nil
+}
+
+// Fold2 collapses a Stream2[K, V] into a single value of type U.
+func Fold2[K, V, U any](s Stream2[K, V], initial U, fn func(U, K, V) U) (U, error) {
+	seq, errp := s.open()
+	acc := initial
+	for k, v := range seq {
+		// Check for upstream errors before each step
+		if *errp != nil {
+			break
+		}
+		acc = fn(acc, k, v)
+	}
+	if err := *errp; err != nil {
+		return initial, err
+	}
+	return acc, nil
+}
+
+// GroupBy2 collects the values of each key into a slice, keeping their arrival order.
+func GroupBy2[K comparable, V any](s Stream2[K, V]) (map[K][]V, error) {
+	res := make(map[K][]V)
+	err := s.ForEach(func(k K, v V) {
+		res[k] = append(res[k], v)
+	})
+	return res, err
+}
+
+// MaxByValue returns the pair with the largest value.
+// Ties keep the earliest pair. Returns an error if the stream is empty.
+func MaxByValue[K any, V cmp.Ordered](s Stream2[K, V]) (K, V, error) {
+	return s.Reduce(func(k1 K, v1 V, k2 K, v2 V) (K, V) {
+		if v2 > v1 {
+			return k2, v2
+		}
+		return k1, v1
+	})
+}
+
+// MinByValue returns the pair with the smallest value.
+// Ties keep the earliest pair. Returns an error if the stream is empty.
+func MinByValue[K any, V cmp.Ordered](s Stream2[K, V]) (K, V, error) {
+	return s.Reduce(func(k1 K, v1 V, k2 K, v2 V) (K, V) {
+		if v2 < v1 {
+			return k2, v2
+		}
+		return k1, v1
+	})
+}
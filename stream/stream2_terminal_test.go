@@ -0,0 +1,72 @@
+package stream_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/wesleylin/basin/stream"
+)
+
+func TestStream2_TerminalExtensions(t *testing.T) {
+	t.Run("Last: Successful", func(t *testing.T) {
+		k, v, err := orderedPairs([]string{"a", "b", "c"}, []int{1, 2, 3}).Last()
+		if err != nil || k != "c" || v != 3 {
+			t.Errorf("expected c:3, got %s:%d (err: %v)", k, v, err)
+		}
+	})
+
+	t.Run("Last: Empty Stream", func(t *testing.T) {
+		_, _, err := stream.FromMap(map[string]int{}).Last()
+		if err == nil {
+			t.Error("expected error for empty stream Last()")
+		}
+	})
+
+	t.Run("Fold2", func(t *testing.T) {
+		total, err := stream.Fold2(orderedPairs([]string{"ab", "c"}, []int{10, 20}), 0, func(acc int, k string, v int) int {
+			return acc + len(k)*v
+		})
+		if err != nil || total != 40 {
+			t.Errorf("expected 40, got %d (err: %v)", total, err)
+		}
+	})
+
+	t.Run("GroupBy2", func(t *testing.T) {
+		grouped, err := stream.GroupBy2(orderedPairs([]string{"x", "y", "x"}, []int{1, 2, 3}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(grouped["x"], []int{1, 3}) || !slices.Equal(grouped["y"], []int{2}) {
+			t.Errorf("grouping failed: %v", grouped)
+		}
+	})
+
+	t.Run("Min/MaxByValue", func(t *testing.T) {
+		s := orderedPairs([]string{"a", "b", "c", "d"}, []int{5, 1, 9, 1})
+		k, v, err := stream.MaxByValue(s)
+		if err != nil || k != "c" || v != 9 {
+			t.Errorf("expected c:9, got %s:%d (err: %v)", k, v, err)
+		}
+
+		s = orderedPairs([]string{"a", "b", "c", "d"}, []int{5, 1, 9, 1})
+		k, v, err = stream.MinByValue(s)
+		if err != nil || k != "b" || v != 1 {
+			t.Errorf("expected b:1 (earliest tie), got %s:%d (err: %v)", k, v, err)
+		}
+	})
+
+	t.Run("Terminal: Error Handling", func(t *testing.T) {
+		var errSource = fmt.Errorf("disk error")
+		s := stream.New2(func(yield func(string, int) bool) {
+			yield("a", 1)
+		}, &errSource)
+
+		if _, _, err := s.Last(); err != errSource {
+			t.Errorf("expected %v, got %v", errSource, err)
+		}
+		if _, err := stream.GroupBy2(s); err != errSource {
+			t.Errorf("expected %v, got %v", errSource, err)
+		}
+	})
+}
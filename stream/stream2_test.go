@@ -129,3 +129,103 @@ func TestStream2_Reduce(t *testing.T) {
 		}
 	})
 }
+
+func TestStream2_Skip(t *testing.T) {
+	s := stream.New2(func(yield func(int, string) bool) {
+		for i, v := range []string{"a", "b", "c", "d"} {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}, nil)
+
+	results, err := s.Skip(2).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Key != 2 || results[1].Value != "d" {
+		t.Errorf("expected [2:c 3:d], got %v", results)
+	}
+}
+
+// orderedPairs builds a deterministic Stream2 from alternating keys and values.
+func orderedPairs(keys []string, values []int) stream.Stream2[string, int] {
+	return stream.FromSeq2(func(yield func(string, int) bool) {
+		for i, k := range keys {
+			if !yield(k, values[i]) {
+				return
+			}
+		}
+	})
+}
+
+func TestStream2_ShortCircuiting(t *testing.T) {
+	t.Run("First returns the first pair", func(t *testing.T) {
+		k, v, err := orderedPairs([]string{"a", "b"}, []int{1, 2}).First()
+		if err != nil || k != "a" || v != 1 {
+			t.Errorf("expected a:1, got %s:%d (err: %v)", k, v, err)
+		}
+	})
+
+	t.Run("Any short-circuits", func(t *testing.T) {
+		calls := 0
+		found, err := orderedPairs([]string{"a", "b", "c"}, []int{1, 2, 3}).Any(func(k string, v int) bool {
+			calls++
+			return v == 2
+		})
+		if err != nil || !found || calls != 2 {
+			t.Errorf("expected match after 2 calls, got %v after %d (err: %v)", found, calls, err)
+		}
+	})
+
+	t.Run("All fails on first mismatch", func(t *testing.T) {
+		ok, err := orderedPairs([]string{"a", "b"}, []int{1, -1}).All(func(k string, v int) bool {
+			return v > 0
+		})
+		if err != nil || ok {
+			t.Errorf("expected false, got %v (err: %v)", ok, err)
+		}
+	})
+
+	t.Run("ForEach stops on error", func(t *testing.T) {
+		var errSource error
+		s := stream.New2(func(yield func(string, int) bool) {
+			yield("a", 1)
+			errSource = fmt.Errorf("shard failure")
+			yield("b", 2)
+		}, &errSource)
+
+		count := 0
+		err := s.ForEach(func(string, int) { count++ })
+		if err == nil || count != 1 {
+			t.Errorf("expected error after 1 pair, got %v after %d", err, count)
+		}
+	})
+}
+
+func TestStream2_Bridges(t *testing.T) {
+	t.Run("Swap", func(t *testing.T) {
+		results, err := orderedPairs([]string{"a", "b"}, []int{1, 2}).Swap().Collect()
+		if err != nil || len(results) != 2 || results[1].Key != 2 || results[1].Value != "b" {
+			t.Errorf("unexpected swap result: %v (err: %v)", results, err)
+		}
+	})
+
+	t.Run("MapKeys", func(t *testing.T) {
+		s := stream.MapKeys(orderedPairs([]string{"a", "bb"}, []int{1, 2}), func(k string) int {
+			return len(k)
+		})
+		results, err := s.Collect()
+		if err != nil || results[1].Key != 2 || results[1].Value != 2 {
+			t.Errorf("unexpected MapKeys result: %v (err: %v)", results, err)
+		}
+	})
+
+	t.Run("Pairs", func(t *testing.T) {
+		pairs, err := stream.Pairs(orderedPairs([]string{"a", "b"}, []int{1, 2})).Collect()
+		want := []stream.Pair[string, int]{{"a", 1}, {"b", 2}}
+		if err != nil || len(pairs) != 2 || pairs[0] != want[0] || pairs[1] != want[1] {
+			t.Errorf("expected %v, got %v (err: %v)", want, pairs, err)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package stream
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Buffer runs everything upstream of it in its own goroutine and hands items
+// downstream through a queue of n items, so a slow producer and a slow consumer
+// can work at the same time instead of in lock-step.
+//
+// Upstream errors are reported by the terminal as usual, once the queued items
+// before the failure have been consumed. A panic upstream is re-raised in the
+// consuming goroutine. When downstream stops early, the producer goroutine is
+// stopped and waited for, so upstream cleanups such as OnClose have run by the
+// time the terminal returns. Operators downstream of Buffer get their own
+// error slot even when the source has a caller-owned one, which only receives
+// downstream errors once the producer has stopped. n must be positive.
+func (s Stream[T]) Buffer(n int) Stream[T] {
+	if n < 1 {
+		panic(fmt.Sprintf("stream: buffer size must be positive, got %d", n))
+	}
+	// Downstream always gets a fresh slot: a caller-owned one is written by
+	// the source from the producer goroutine while the consumer reads its own.
+	return Stream[T]{
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			// upstream keeps its own live wire, written by the producer
+			// goroutine and only copied over once that goroutine is done
+			seq, upErr := s.open()
+
+			return func(yield func(T) bool) {
+				items := make(chan T, n)
+				stop := make(chan struct{})
+				finished := make(chan struct{})
+				var panicked any
+
+				go func() {
+					defer close(finished)
+					defer close(items)
+					defer func() {
+						panicked = recover()
+					}()
+					for v := range seq {
+						select {
+						case items <- v:
+						case <-stop:
+							return
+						}
+					}
+				}()
+
+				defer func() {
+					close(stop)
+					<-finished
+					// keep reporting to a caller-owned slot, as the rest of
+					// the pipeline would, once the producer can't touch it
+					if s.err != nil && *s.err == nil && *errp != nil {
+						*s.err = *errp
+					}
+				}()
+
+				for v := range items {
+					if !yield(v) {
+						return
+					}
+				}
+
+				// items is closed, so the producer has finished
+				<-finished
+				if panicked != nil {
+					panic(panicked)
+				}
+				if *upErr != nil {
+					*errp = *upErr
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBuffer(t *testing.T) {
+	t.Run("Preserves Order", func(t *testing.T) {
+		got, err := FromSlice([]int{1, 2, 3, 4, 5}).Buffer(2).Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("expected [1 2 3 4 5], got %v (err: %v)", got, err)
+		}
+	})
+
+	t.Run("Producer Runs Ahead Of Consumer", func(t *testing.T) {
+		produced := make(chan int, 10)
+		src := FromSeq(func(yield func(int) bool) {
+			for i := range 3 {
+				produced <- i
+				if !yield(i) {
+					return
+				}
+			}
+		})
+
+		err := src.Buffer(3).ForEach(func(n int) {
+			if n != 0 {
+				return
+			}
+			// while we hold item 0, the producer should reach item 2
+			for want := range 3 {
+				select {
+				case got := <-produced:
+					if got != want {
+						t.Errorf("expected %d, got %d", want, got)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("producer stalled before item %d", want)
+				}
+			}
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Propagates Upstream Error", func(t *testing.T) {
+		boom := errors.New("boom")
+		s := MapErr(FromSlice([]int{1, 2, 3}), func(n int) (int, error) {
+			if n == 3 {
+				return 0, boom
+			}
+			return n, nil
+		})
+
+		var seen []int
+		err := s.Buffer(1).ForEach(func(n int) { seen = append(seen, n) })
+		if !errors.Is(err, boom) {
+			t.Errorf("expected boom, got %v", err)
+		}
+		if !slices.Equal(seen, []int{1, 2}) {
+			t.Errorf("expected the items before the error, got %v", seen)
+		}
+	})
+
+	t.Run("Caller-Owned Error Slot", func(t *testing.T) {
+		var errSource error
+		boom := errors.New("boom")
+		src := New(func(yield func(int) bool) {
+			for i := range 10 {
+				if !yield(i) {
+					return
+				}
+			}
+			errSource = boom
+		}, &errSource)
+
+		// Skip reads its live wire on every item while the producer runs
+		seen := 0
+		err := src.Buffer(4).Skip(1).ForEach(func(int) { seen++ })
+		if !errors.Is(err, boom) || seen != 9 {
+			t.Errorf("expected 9 items and boom, got %d (err: %v)", seen, err)
+		}
+
+		errSource = nil
+		bad := errors.New("bad item")
+		_, err = MapErr(src.Buffer(4), func(n int) (int, error) {
+			if n == 2 {
+				return 0, bad
+			}
+			return n, nil
+		}).Collect()
+		if !errors.Is(err, bad) || !errors.Is(errSource, bad) {
+			t.Errorf("expected bad in both the terminal and the caller's slot, got %v and %v", err, errSource)
+		}
+	})
+
+	t.Run("Propagates Upstream Panic", func(t *testing.T) {
+		s := FromSeq(func(yield func(int) bool) {
+			yield(1)
+			panic("kaboom")
+		})
+
+		defer func() {
+			if r := recover(); r != "kaboom" {
+				t.Errorf("expected kaboom, got %v", r)
+			}
+		}()
+		_, _ = s.Buffer(1).Collect()
+		t.Error("expected Collect to panic")
+	})
+
+	t.Run("Early Break Stops Producer", func(t *testing.T) {
+		closed := false
+		infinite := FromSeq(func(yield func(int) bool) {
+			for i := 0; ; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}).OnClose(func() error {
+			closed = true
+			return nil
+		})
+
+		first, err := infinite.Buffer(4).First()
+		if err != nil || first != 0 {
+			t.Fatalf("expected 0, got %d (err: %v)", first, err)
+		}
+		// the producer has been waited for, so reading closed is race free
+		if !closed {
+			t.Error("expected upstream cleanup to run before First returned")
+		}
+	})
+}
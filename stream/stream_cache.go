@@ -0,0 +1,126 @@
+package stream
+
+import (
+	"errors"
+	"iter"
+	"sync"
+)
+
+// ErrCacheLimit is reported by a run of a cached stream that needs an item
+// the cache dropped because its limit was reached.
+var ErrCacheLimit = errors.New("stream: cache limit exceeded")
+
+// errSourcePanic is reported by the other runs of a shared source (Cache,
+// Tee, Partition) after it panicked during one of them, since the items it
+// would have produced are lost.
+var errSourcePanic = errors.New("stream: shared source panicked")
+
+// CacheOption configures Cache.
+type CacheOption func(*cacheConfig)
+
+type cacheConfig struct {
+	limit int
+}
+
+// CacheLimit caps the number of buffered items at n. A source longer than n
+// is still streamed in full by the run that reaches the limit, but the extra
+// items are not kept, so other runs that need them fail with ErrCacheLimit.
+func CacheLimit(n int) CacheOption {
+	return func(c *cacheConfig) { c.limit = max(n, 0) }
+}
+
+// Cache memoizes s so it can be run several times, even when the source can
+// only be consumed once, such as a reader or a channel.
+//
+// Items are buffered lazily: the source is only pulled as far as the furthest
+// run has asked for. Later runs, and runs concurrent with the first, replay
+// from the buffer and pull more only when they get ahead of it. A run that
+// stops early leaves the source paused where it is, for the next run to resume.
+//
+// The source's error is kept as well, so every run that reaches the point
+// where the source failed returns the same error.
+func (s Stream[T]) Cache(opts ...CacheOption) Stream[T] {
+	var cfg cacheConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	c := &cache[T]{limit: cfg.limit}
+	c.cond = sync.NewCond(&c.mu)
+	c.src = newPuller(s, &c.mu, c.cond)
+
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			return func(yield func(T) bool) {
+				r := &cacheRun{}
+				for {
+					v, ok, err := c.next(r)
+					if err != nil {
+						*errp = err
+						return
+					}
+					if !ok || !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// cache is the buffer shared by every run of a cached stream.
+type cache[T any] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	src   *puller[T] // its error is replayed to every run
+	limit int        // max buffered items, 0 = unbounded
+
+	items    []T
+	overflow *cacheRun // the run that went past the limit and now owns the source
+}
+
+// cacheRun is the read position of one run of a cached stream.
+type cacheRun struct {
+	pos int
+}
+
+// next returns the next item for run r, pulling from the source when the buffer is behind.
+func (c *cache[T]) next(r *cacheRun) (T, bool, error) {
+	var zero T
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		if r.pos < len(c.items) {
+			r.pos++
+			return c.items[r.pos-1], true, nil
+		}
+		if c.overflow != nil && c.overflow != r {
+			return zero, false, ErrCacheLimit
+		}
+		if c.src.done {
+			return zero, false, c.src.err
+		}
+		if c.src.pulling {
+			c.cond.Wait()
+			continue
+		}
+
+		v, ok := c.src.pull()
+		if !ok {
+			continue
+		}
+		c.src.release()
+		if c.overflow == nil && c.limit > 0 && len(c.items) >= c.limit {
+			// keep streaming to r, but stop buffering for everyone else
+			c.overflow = r
+		}
+		if c.overflow == nil {
+			c.items = append(c.items, v)
+			r.pos++
+		}
+		return v, true, nil
+	}
+}
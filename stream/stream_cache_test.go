@@ -0,0 +1,163 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// fromChan is a single-pass source: a second run sees an empty channel.
+func fromChan(items ...int) (Stream[int], *int) {
+	ch := make(chan int, len(items))
+	for _, v := range items {
+		ch <- v
+	}
+	close(ch)
+	pulls := 0
+	return FromSeq(func(yield func(int) bool) {
+		for v := range ch {
+			pulls++
+			if !yield(v) {
+				return
+			}
+		}
+	}), &pulls
+}
+
+func TestCache(t *testing.T) {
+	t.Run("Replays A Single Pass Source", func(t *testing.T) {
+		src, pulls := fromChan(1, 2, 3)
+		s := src.Cache()
+
+		n, err := s.Count()
+		if err != nil || n != 3 {
+			t.Fatalf("expected 3, got %d (err: %v)", n, err)
+		}
+		got, err := s.Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v (err: %v)", got, err)
+		}
+		if *pulls != 3 {
+			t.Errorf("expected the source to be read once, got %d pulls", *pulls)
+		}
+	})
+
+	t.Run("Buffers Lazily And Resumes", func(t *testing.T) {
+		src, pulls := fromChan(1, 2, 3, 4)
+		s := src.Cache()
+
+		first, err := s.First()
+		if err != nil || first != 1 {
+			t.Fatalf("expected 1, got %d (err: %v)", first, err)
+		}
+		if *pulls != 1 {
+			t.Errorf("expected 1 pull after First, got %d", *pulls)
+		}
+
+		got, err := s.Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3, 4}) {
+			t.Errorf("expected [1 2 3 4], got %v (err: %v)", got, err)
+		}
+		if *pulls != 4 {
+			t.Errorf("expected 4 pulls, got %d", *pulls)
+		}
+	})
+
+	t.Run("Concurrent Runs", func(t *testing.T) {
+		src, pulls := fromChan(1, 2, 3, 4, 5, 6, 7, 8)
+		s := src.Cache()
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got, err := s.Collect()
+				if err != nil || len(got) != 8 {
+					t.Errorf("got %v (err: %v)", got, err)
+				}
+			}()
+		}
+		wg.Wait()
+		if *pulls != 8 {
+			t.Errorf("expected 8 pulls, got %d", *pulls)
+		}
+	})
+
+	t.Run("Replays Source Error", func(t *testing.T) {
+		boom := errors.New("boom")
+		calls := 0
+		s := MapErr(FromSlice([]int{1, 2, 3}), func(n int) (int, error) {
+			calls++
+			if n == 3 {
+				return 0, boom
+			}
+			return n, nil
+		}).Cache()
+
+		for run := range 2 {
+			got, err := s.Collect()
+			if !errors.Is(err, boom) || got != nil {
+				t.Errorf("run %d: expected boom, got %v (err: %v)", run, got, err)
+			}
+		}
+		if calls != 3 {
+			t.Errorf("expected MapErr to run once per item, got %d calls", calls)
+		}
+
+		// runs that stop before the failure point don't see it
+		first, err := s.First()
+		if err != nil || first != 1 {
+			t.Errorf("expected 1, got %d (err: %v)", first, err)
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		src, _ := fromChan(1, 2, 3, 4)
+		s := src.Cache(CacheLimit(2))
+
+		got, err := s.Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3, 4}) {
+			t.Fatalf("first run: expected [1 2 3 4], got %v (err: %v)", got, err)
+		}
+
+		// the buffered prefix still replays
+		var prefix []int
+		for v := range s.Seq() {
+			prefix = append(prefix, v)
+			if len(prefix) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(prefix, []int{1, 2}) {
+			t.Errorf("expected [1 2], got %v", prefix)
+		}
+
+		if _, err := s.Collect(); !errors.Is(err, ErrCacheLimit) {
+			t.Errorf("expected ErrCacheLimit, got %v", err)
+		}
+	})
+
+	t.Run("Source Panic", func(t *testing.T) {
+		s := FromSeq(func(yield func(int) bool) {
+			if !yield(1) {
+				return
+			}
+			panic("kaboom")
+		}).Cache()
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected the first run to panic")
+				}
+			}()
+			_, _ = s.Collect()
+		}()
+
+		if _, err := s.Collect(); !errors.Is(err, errSourcePanic) {
+			t.Errorf("expected errSourcePanic, got %v", err)
+		}
+	})
+}
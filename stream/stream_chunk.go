@@ -0,0 +1,101 @@
+package stream
+
+import "iter"
+
+// ChunkBy groups consecutive elements that share a key, yielding each run as
+// (key, elements) as soon as the key changes. Unlike GroupBy, a key that
+// reappears later starts a new chunk, and memory is bounded by the longest run.
+// The trailing chunk is dropped if the stream fails.
+func ChunkBy[T any, K comparable](s Stream[T], keyFn func(T) K) Stream2[K, []T] {
+	return Stream2[K, []T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[K, []T] {
+			seq := s.run(errp)
+			return func(yield func(K, []T) bool) {
+				var key K
+				var chunk []T
+
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+					k := keyFn(v)
+					if len(chunk) > 0 && k != key {
+						if !yield(key, chunk) {
+							return
+						}
+						// the yielded chunk belongs to the consumer now
+						chunk = nil
+					}
+					key = k
+					chunk = append(chunk, v)
+				}
+
+				if *errp == nil && len(chunk) > 0 {
+					yield(key, chunk)
+				}
+			}
+		},
+	}
+}
+
+// RunLength yields each run of equal consecutive elements as (value, count).
+// Only the current value is held, so memory is constant.
+func RunLength[T comparable](s Stream[T]) Stream2[T, int] {
+	return Stream2[T, int]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[T, int] {
+			seq := s.run(errp)
+			return func(yield func(T, int) bool) {
+				var cur T
+				n := 0
+
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+					if n > 0 && v != cur {
+						if !yield(cur, n) {
+							return
+						}
+						n = 0
+					}
+					cur = v
+					n++
+				}
+
+				if *errp == nil && n > 0 {
+					yield(cur, n)
+				}
+			}
+		},
+	}
+}
+
+// DedupConsecutive drops elements equal to the one just before them,
+// e.g. 1 1 2 1 1 becomes 1 2 1.
+func DedupConsecutive[T comparable](s Stream[T]) Stream[T] {
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				var prev T
+				first := true
+
+				for v := range seq {
+					if !first && v == prev {
+						continue
+					}
+					prev, first = v, false
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
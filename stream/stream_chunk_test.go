@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestChunkBy(t *testing.T) {
+	lines := []string{"a:1", "a:2", "b:1", "a:3", "a:4"}
+	reqID := func(line string) string { return strings.Split(line, ":")[0] }
+
+	t.Run("Groups Consecutive Runs", func(t *testing.T) {
+		var keys []string
+		var chunks [][]string
+		err := ChunkBy(FromSlice(lines), reqID).ForEach(func(k string, chunk []string) {
+			keys = append(keys, k)
+			chunks = append(chunks, chunk)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(keys, []string{"a", "b", "a"}) {
+			t.Errorf("unexpected keys %v", keys)
+		}
+		want := [][]string{{"a:1", "a:2"}, {"b:1"}, {"a:3", "a:4"}}
+		if !slices.EqualFunc(chunks, want, slices.Equal) {
+			t.Errorf("got %v, want %v", chunks, want)
+		}
+	})
+
+	t.Run("Drops Trailing Chunk On Error", func(t *testing.T) {
+		boom := errors.New("boom")
+		s := MapErr(FromSlice(lines), func(line string) (string, error) {
+			if line == "a:4" {
+				return "", boom
+			}
+			return line, nil
+		})
+		var keys []string
+		err := ChunkBy(s, reqID).ForEach(func(k string, _ []string) { keys = append(keys, k) })
+		if !errors.Is(err, boom) {
+			t.Errorf("expected boom, got %v", err)
+		}
+		if !slices.Equal(keys, []string{"a", "b"}) {
+			t.Errorf("expected only the completed chunks, got %v", keys)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		n, err := ChunkBy(FromSlice([]string{}), reqID).Count()
+		if err != nil || n != 0 {
+			t.Errorf("expected 0 chunks, got %d (err: %v)", n, err)
+		}
+	})
+}
+
+func TestRunLength(t *testing.T) {
+	got, err := RunLength(FromSlice([]rune("aaabccdddd"))).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Pair[rune, int]{{'a', 3}, {'b', 1}, {'c', 2}, {'d', 4}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDedupConsecutive(t *testing.T) {
+	got, err := DedupConsecutive(FromSlice([]int{1, 1, 2, 1, 1, 3, 3})).Collect()
+	if err != nil || !slices.Equal(got, []int{1, 2, 1, 3}) {
+		t.Errorf("expected [1 2 1 3], got %v (err: %v)", got, err)
+	}
+
+	// the zero value is not special
+	got, _ = DedupConsecutive(FromSlice([]int{0, 0, 1})).Collect()
+	if !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("expected [0 1], got %v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package stream
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"sync"
+)
+
+// NewWithCloser wraps an iterator that owns a resource, such as an *os.File or
+// *sql.Rows. The closer runs exactly once when the first run of the stream
+// ends, whether it was drained, short-circuited by First/Any/Take, or failed.
+// A Close error is joined into the error returned by the terminal.
+func NewWithCloser[T any](seq iter.Seq[T], closer io.Closer) Stream[T] {
+	return New(seq, nil).OnClose(closer.Close)
+}
+
+// OnClose registers fn to run exactly once when iteration of the stream ends,
+// however it ends. On a stream that is run several times, fn runs at the end
+// of the first run only, since it usually releases the source. Cleanups
+// registered at several points of a pipeline all run, upstream first.
+// A non-nil error from fn is joined into the terminal's error.
+func (s Stream[T]) OnClose(fn func() error) Stream[T] {
+	once := onceCloser(fn)
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				defer once(errp)
+				for v := range seq {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// OnClose registers fn to run exactly once when iteration of the stream ends,
+// however it ends. On a stream that is run several times, fn runs at the end
+// of the first run only, since it usually releases the source.
+// A non-nil error from fn is joined into the terminal's error.
+func (s Stream2[K, V]) OnClose(fn func() error) Stream2[K, V] {
+	once := onceCloser(fn)
+	return Stream2[K, V]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[K, V] {
+			seq := s.run(errp)
+			return func(yield func(K, V) bool) {
+				defer once(errp)
+				for k, v := range seq {
+					if !yield(k, v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// onceCloser runs fn at most once and joins its error into the live wire of
+// the run that triggered it. It runs from a defer, so cleanup also happens
+// when the pipeline panics.
+func onceCloser(fn func() error) func(errPtr *error) {
+	var once sync.Once
+	return func(errPtr *error) {
+		once.Do(func() {
+			if err := fn(); err != nil {
+				*errPtr = errors.Join(*errPtr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// fakeRows mimics a resource like *sql.Rows that must be closed.
+type fakeRows struct {
+	closed   int
+	closeErr error
+}
+
+func (r *fakeRows) Close() error {
+	r.closed++
+	return r.closeErr
+}
+
+func (r *fakeRows) seq(n int) func(func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestNewWithCloser(t *testing.T) {
+	t.Run("Closes when drained", func(t *testing.T) {
+		rows := &fakeRows{}
+		got, err := NewWithCloser(rows.seq(3), rows).Collect()
+		if err != nil || !slices.Equal(got, []int{0, 1, 2}) {
+			t.Fatalf("unexpected result %v (err: %v)", got, err)
+		}
+		if rows.closed != 1 {
+			t.Errorf("expected 1 close, got %d", rows.closed)
+		}
+	})
+
+	t.Run("Closes on short circuit", func(t *testing.T) {
+		for name, run := range map[string]func(Stream[int]){
+			"First": func(s Stream[int]) { s.First() },
+			"Any":   func(s Stream[int]) { s.Any(func(n int) bool { return n == 1 }) },
+			"All":   func(s Stream[int]) { s.All(func(n int) bool { return n < 1 }) },
+			"Take":  func(s Stream[int]) { s.Take(2).Collect() },
+		} {
+			rows := &fakeRows{}
+			run(NewWithCloser(rows.seq(100), rows))
+			if rows.closed != 1 {
+				t.Errorf("%s: expected 1 close, got %d", name, rows.closed)
+			}
+		}
+	})
+
+	t.Run("Closes once on upstream error", func(t *testing.T) {
+		rows := &fakeRows{}
+		sentinel := errors.New("bad row")
+		s := MapErr(NewWithCloser(rows.seq(5), rows), func(n int) (int, error) {
+			if n == 2 {
+				return 0, sentinel
+			}
+			return n, nil
+		})
+
+		if _, err := s.Collect(); !errors.Is(err, sentinel) {
+			t.Errorf("expected %v, got %v", sentinel, err)
+		}
+		if rows.closed != 1 {
+			t.Errorf("expected 1 close, got %d", rows.closed)
+		}
+	})
+
+	t.Run("Close error joins the terminal error", func(t *testing.T) {
+		closeErr := errors.New("close failed")
+		rows := &fakeRows{closeErr: closeErr}
+
+		v, err := NewWithCloser(rows.seq(3), rows).First()
+		if !errors.Is(err, closeErr) || v != 0 {
+			t.Errorf("expected %v, got %d (err: %v)", closeErr, v, err)
+		}
+
+		// both the pipeline error and the close error are reported
+		rows = &fakeRows{closeErr: closeErr}
+		sentinel := errors.New("bad row")
+		_, err = MapErr(NewWithCloser(rows.seq(3), rows), func(int) (int, error) {
+			return 0, sentinel
+		}).Count()
+		if !errors.Is(err, sentinel) || !errors.Is(err, closeErr) {
+			t.Errorf("expected joined error, got %v", err)
+		}
+	})
+
+	t.Run("Closes when iteration panics", func(t *testing.T) {
+		rows := &fakeRows{}
+		func() {
+			defer func() { recover() }()
+			NewWithCloser(rows.seq(3), rows).ForEach(func(int) { panic("boom") })
+		}()
+		if rows.closed != 1 {
+			t.Errorf("expected 1 close, got %d", rows.closed)
+		}
+	})
+}
+
+func TestOnClose(t *testing.T) {
+	var order []string
+	s := FromSlice([]int{1, 2, 3}).
+		OnClose(func() error { order = append(order, "source"); return nil }).
+		Filter(func(n int) bool { return n > 1 }).
+		OnClose(func() error { order = append(order, "filter"); return nil })
+
+	if _, err := s.First(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(order, []string{"source", "filter"}) {
+		t.Errorf("unexpected cleanup order: %v", order)
+	}
+}
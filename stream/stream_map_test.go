@@ -149,3 +149,28 @@ func TestFold(t *testing.T) {
 		}
 	})
 }
+
+func TestScan(t *testing.T) {
+	t.Run("Running Sum", func(t *testing.T) {
+		got, err := Scan(FromSlice([]int{1, 2, 3, 4}), 0, func(acc, v int) int {
+			return acc + v
+		}).Collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []int{1, 3, 6, 10}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Stops Early With TakeWhile", func(t *testing.T) {
+		steps := 0
+		got, _ := Scan(FromSlice([]int{1, 2, 3, 4}), "", func(acc string, v int) string {
+			steps++
+			return acc + strconv.Itoa(v)
+		}).TakeWhile(func(acc string) bool { return len(acc) < 3 }).Collect()
+		if !slices.Equal(got, []string{"1", "12"}) || steps != 3 {
+			t.Errorf("got %v after %d steps", got, steps)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package stream
+
+import (
+	"iter"
+	"sync"
+)
+
+// Merge drains every input concurrently, each in its own goroutine, into a
+// single stream in arrival order. The order of items from different inputs is
+// not deterministic; the items of one input keep their relative order.
+//
+// The first error from any input, or a panic, stops every other input and is
+// reported by the terminal; items still in flight are dropped. When downstream
+// stops early, all input goroutines are stopped and waited for before the
+// terminal returns, so their cleanups have run.
+func Merge[T any](streams ...Stream[T]) Stream[T] {
+	return MergeBounded(len(streams), streams...)
+}
+
+// MergeBounded is Merge with at most size items buffered between the inputs
+// and the consumer. A size <= 0 means an unbuffered hand-off.
+func MergeBounded[T any](size int, streams ...Stream[T]) Stream[T] {
+	var metrics *Metrics
+	if len(streams) > 0 {
+		metrics = streams[0].metrics
+	}
+
+	return Stream[T]{
+		metrics: metrics,
+		run: func(errp *error) iter.Seq[T] {
+			// each input writes its own live wire from its own goroutine
+			seqs := make([]iter.Seq[T], len(streams))
+			errs := make([]*error, len(streams))
+			for i, s := range streams {
+				seqs[i], errs[i] = s.open()
+			}
+
+			return func(yield func(T) bool) {
+				m := &merger[T]{
+					out:  make(chan T, max(size, 0)),
+					stop: make(chan struct{}),
+				}
+				var wg sync.WaitGroup
+				for i := range seqs {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						m.drain(seqs[i], errs[i])
+					}()
+				}
+				go func() {
+					wg.Wait()
+					close(m.out)
+				}()
+				defer func() {
+					m.halt()
+					wg.Wait()
+				}()
+
+				for v := range m.out {
+					if m.failed() {
+						break
+					}
+					if !yield(v) {
+						return
+					}
+				}
+
+				m.halt()
+				wg.Wait()
+				if m.panicked != nil {
+					panic(m.panicked)
+				}
+				if m.err != nil {
+					*errp = m.err
+				}
+			}
+		},
+	}
+}
+
+// merger is the state of one run of a Merge.
+type merger[T any] struct {
+	out  chan T
+	stop chan struct{}
+	once sync.Once
+
+	mu       sync.Mutex
+	err      error
+	panicked any
+}
+
+// drain forwards one input to out until it ends or the merge stops.
+func (m *merger[T]) drain(seq iter.Seq[T], errp *error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.fail(nil, r)
+		}
+	}()
+	for v := range seq {
+		select {
+		case m.out <- v:
+		case <-m.stop:
+			return
+		}
+	}
+	if *errp != nil {
+		m.fail(*errp, nil)
+	}
+}
+
+// fail records the first error or panic and stops every input.
+func (m *merger[T]) fail(err error, panicked any) {
+	m.mu.Lock()
+	if m.err == nil && m.panicked == nil {
+		m.err, m.panicked = err, panicked
+	}
+	m.mu.Unlock()
+	m.halt()
+}
+
+func (m *merger[T]) failed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err != nil || m.panicked != nil
+}
+
+func (m *merger[T]) halt() {
+	m.once.Do(func() { close(m.stop) })
+}
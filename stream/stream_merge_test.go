@@ -0,0 +1,137 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("Drains Every Input", func(t *testing.T) {
+		got, err := Merge(ints(100), ints(100), ints(100)).Collect()
+		if err != nil || len(got) != 300 {
+			t.Fatalf("expected 300 items, got %d (err: %v)", len(got), err)
+		}
+		slices.Sort(got)
+		for i, v := range got {
+			if v != i/3 {
+				t.Fatalf("unexpected items %v", got)
+			}
+		}
+	})
+
+	t.Run("Keeps Per-Input Order", func(t *testing.T) {
+		odds := Map(ints(50), func(n int) int { return 2*n + 1 })
+		evens := Map(ints(50), func(n int) int { return 2 * n })
+		var lastOdd, lastEven = -1, -2
+		err := MergeBounded(1, odds, evens).ForEach(func(n int) {
+			last := &lastEven
+			if n%2 == 1 {
+				last = &lastOdd
+			}
+			if n < *last {
+				t.Errorf("%d arrived after %d", n, *last)
+			}
+			*last = n
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Inputs Run Concurrently", func(t *testing.T) {
+		// each input blocks until the other has produced, so a sequential
+		// Concat-style drain would deadlock
+		a, b := make(chan struct{}), make(chan struct{})
+		left := FromSeq(func(yield func(int) bool) {
+			close(a)
+			<-b
+			yield(1)
+		})
+		right := FromSeq(func(yield func(int) bool) {
+			close(b)
+			<-a
+			yield(2)
+		})
+
+		done := make(chan []int)
+		go func() {
+			got, _ := Merge(left, right).Collect()
+			done <- got
+		}()
+		select {
+		case got := <-done:
+			if len(got) != 2 {
+				t.Errorf("expected 2 items, got %v", got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("inputs were not drained concurrently")
+		}
+	})
+
+	t.Run("First Error Stops Everything", func(t *testing.T) {
+		boom := errors.New("boom")
+		failing := MapErr(ints(10), func(n int) (int, error) {
+			if n == 5 {
+				return 0, boom
+			}
+			return n, nil
+		})
+		endless := FromSeq(func(yield func(int) bool) {
+			for i := 0; ; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		})
+
+		_, err := Merge(failing, endless).Collect()
+		if !errors.Is(err, boom) {
+			t.Errorf("expected boom, got %v", err)
+		}
+	})
+
+	t.Run("Early Exit Stops Inputs", func(t *testing.T) {
+		var closed atomic.Int64
+		endless := func() Stream[int] {
+			return FromSeq(func(yield func(int) bool) {
+				for i := 0; ; i++ {
+					if !yield(i) {
+						return
+					}
+				}
+			}).OnClose(func() error {
+				closed.Add(1)
+				return nil
+			})
+		}
+
+		got, err := Merge(endless(), endless(), endless()).First()
+		if err != nil || got != 0 {
+			t.Errorf("expected 0, got %d (err: %v)", got, err)
+		}
+		if n := closed.Load(); n != 3 {
+			t.Errorf("expected every input to be stopped, %d were", n)
+		}
+	})
+
+	t.Run("Input Panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "kaboom" {
+				t.Errorf("expected kaboom, got %v", r)
+			}
+		}()
+		bad := FromSeq(func(yield func(int) bool) { panic("kaboom") })
+		_, _ = Merge(ints(10), bad).Collect()
+		t.Error("expected Collect to panic")
+	})
+
+	t.Run("No Inputs", func(t *testing.T) {
+		n, err := Merge[int]().Count()
+		if err != nil || n != 0 {
+			t.Errorf("expected 0, got %d (err: %v)", n, err)
+		}
+	})
+}
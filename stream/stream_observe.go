@@ -0,0 +1,272 @@
+package stream
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Instrumentation is opt-in. Call Instrument on a stream, wrap the interesting
+// parts of the pipeline in named Stages, then read Metrics after the terminal:
+//
+//	s := stream.FromSlice(lines).Instrument(nil)
+//	parsed := stream.Stage(s, "parse", func(s stream.Stream[string]) stream.Stream[Row] {
+//		return stream.MapErr(...)
+//	})
+//	rows, err := parsed.Collect()
+//	for _, m := range parsed.Metrics() { ... }
+//
+// Without Instrument, Stage simply applies its operator and nothing is recorded.
+
+// StageMetrics is a snapshot of one stage's counters.
+// Duration is the time spent inside the stage itself, excluding the time
+// spent upstream producing items and downstream consuming them.
+type StageMetrics struct {
+	Name     string
+	In       int64
+	Out      int64
+	Errors   int64
+	Duration time.Duration
+}
+
+// Metrics is the registry shared by every stage of an instrumented pipeline.
+type Metrics struct {
+	mu     sync.Mutex
+	stages []*stageCounters
+	logger *slog.Logger
+}
+
+type stageCounters struct {
+	name     string
+	in       atomic.Int64
+	out      atomic.Int64
+	errors   atomic.Int64
+	duration atomic.Int64
+}
+
+func (m *Metrics) register(name string) *stageCounters {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := &stageCounters{name: name}
+	m.stages = append(m.stages, c)
+	return c
+}
+
+// Snapshot returns the counters of every stage in the order they were declared.
+func (m *Metrics) Snapshot() []StageMetrics {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := make([]StageMetrics, len(m.stages))
+	for i, c := range m.stages {
+		res[i] = StageMetrics{
+			Name:     c.name,
+			In:       c.in.Load(),
+			Out:      c.out.Load(),
+			Errors:   c.errors.Load(),
+			Duration: time.Duration(max(c.duration.Load(), 0)),
+		}
+	}
+	return res
+}
+
+// Instrument turns on metrics for every Stage declared downstream of s.
+// If logger is non-nil, stage start, stop and errors are logged to it at debug
+// and error level.
+func (s Stream[T]) Instrument(logger *slog.Logger) Stream[T] {
+	return Stream[T]{
+		err:     s.err,
+		metrics: &Metrics{logger: logger},
+		run:     s.run,
+	}
+}
+
+// Metrics returns the counters of the stages upstream of s,
+// or nil if the stream is not instrumented.
+func (s Stream[T]) Metrics() []StageMetrics {
+	return s.metrics.Snapshot()
+}
+
+// Metrics returns the counters of the stages upstream of s,
+// or nil if the stream is not instrumented.
+func (s Stream2[K, V]) Metrics() []StageMetrics {
+	return s.metrics.Snapshot()
+}
+
+// Stage applies op to s as a named, measured section of the pipeline.
+// On an uninstrumented stream it is exactly op(s).
+//
+// Duration is measured at the two boundaries independently: the out side
+// adds the time between asking op for an item and getting it, the in side
+// subtracts the time op spends waiting on upstream. Operators that run their
+// input concurrently, such as Buffer, overlap the two, so their Duration is
+// only approximate.
+func Stage[T, R any](s Stream[T], name string, op func(Stream[T]) Stream[R]) Stream[R] {
+	if s.metrics == nil {
+		return op(s)
+	}
+
+	runs := &stageRuns{counters: s.metrics.register(name), logger: s.metrics.logger}
+
+	// in: the boundary where upstream hands items to op
+	in := Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				r := runs.acquire(errp)
+				defer runs.release(r)
+
+				// control is upstream from here until an item arrives
+				r.leave()
+				since := time.Now()
+				defer func() {
+					r.counters.duration.Add(-int64(time.Since(since)))
+					r.enter()
+				}()
+				for v := range seq {
+					r.counters.duration.Add(-int64(time.Since(since)))
+					r.counters.in.Add(1)
+					r.enter()
+					ok := yield(v)
+					r.leave()
+					since = time.Now()
+					if !ok {
+						return
+					}
+				}
+			}
+		},
+	}
+	out := op(in)
+
+	// out: the boundary where op hands items downstream
+	return Stream[R]{
+		err:     out.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[R] {
+			seq := out.run(errp)
+			return func(yield func(R) bool) {
+				r := runs.acquire(errp)
+				defer runs.release(r)
+
+				r.start()
+				since := time.Now()
+				defer func() {
+					r.counters.duration.Add(int64(time.Since(since)))
+					r.stop()
+				}()
+				for v := range seq {
+					r.counters.duration.Add(int64(time.Since(since)))
+					r.counters.out.Add(1)
+					r.leave()
+					ok := yield(v)
+					r.enter()
+					since = time.Now()
+					if !ok {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// stageRuns holds the stageRuns of a stage's in-flight runs, keyed by their
+// live wire. The out and in boundaries of one run share the same wire, and so
+// the same stageRun, unless op opens its input on a wire of its own (Cache,
+// Tee), in which case the input's run is tracked separately.
+type stageRuns struct {
+	counters *stageCounters
+	logger   *slog.Logger
+
+	mu   sync.Mutex
+	runs map[*error]*stageRun
+}
+
+func (rs *stageRuns) acquire(errp *error) *stageRun {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	r, ok := rs.runs[errp]
+	if !ok {
+		if rs.runs == nil {
+			rs.runs = make(map[*error]*stageRun)
+		}
+		r = &stageRun{counters: rs.counters, logger: rs.logger, err: errp}
+		rs.runs[errp] = r
+	}
+	r.refs++
+	return r
+}
+
+func (rs *stageRuns) release(r *stageRun) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if r.refs--; r.refs == 0 {
+		delete(rs.runs, r.err)
+	}
+}
+
+// stageRun tracks whether control is inside a stage's operator, to blame
+// errors on it only if the live wire trips while control is inside it.
+type stageRun struct {
+	counters *stageCounters
+	logger   *slog.Logger
+	err      *error
+	refs     int // guarded by stageRuns.mu
+
+	mu     sync.Mutex // guards blamed across runs sharing a caller-owned wire
+	blamed bool
+}
+
+func (r *stageRun) start() {
+	if r.logger != nil {
+		r.logger.Debug("stage start", "stage", r.counters.name)
+	}
+	r.enter()
+}
+
+func (r *stageRun) stop() {
+	r.leave()
+	if r.logger != nil {
+		r.logger.Debug("stage stop",
+			"stage", r.counters.name,
+			"in", r.counters.in.Load(),
+			"out", r.counters.out.Load(),
+			"duration", time.Duration(max(r.counters.duration.Load(), 0)),
+		)
+	}
+}
+
+// enter is called as control passes into op.
+func (r *stageRun) enter() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// an error that was already set came from outside, not from us
+	if *r.err != nil {
+		r.blamed = true
+	}
+}
+
+// leave is called as control passes out of op.
+func (r *stageRun) leave() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.blamed && *r.err != nil {
+		r.blamed = true
+		r.counters.errors.Add(1)
+		if r.logger != nil {
+			r.logger.LogAttrs(context.Background(), slog.LevelError, "stage error",
+				slog.String("stage", r.counters.name),
+				slog.Any("error", *r.err),
+			)
+		}
+	}
+}
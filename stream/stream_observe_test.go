@@ -0,0 +1,131 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStage(t *testing.T) {
+	t.Run("Records in, out and time per stage", func(t *testing.T) {
+		s := FromSlice([]int{1, 2, 3, 4, 5, 6}).Instrument(nil)
+
+		evens := Stage(s, "evens", func(s Stream[int]) Stream[int] {
+			return s.Filter(func(n int) bool { return n%2 == 0 })
+		})
+		labels := Stage(evens, "label", func(s Stream[int]) Stream[string] {
+			return Map(s, func(n int) string {
+				time.Sleep(time.Millisecond)
+				return strconv.Itoa(n)
+			})
+		})
+
+		got, err := labels.Take(2).Collect()
+		if err != nil || len(got) != 2 {
+			t.Fatalf("unexpected result %v (err: %v)", got, err)
+		}
+
+		m := labels.Metrics()
+		if len(m) != 2 || m[0].Name != "evens" || m[1].Name != "label" {
+			t.Fatalf("unexpected stages: %+v", m)
+		}
+		// Take pulls one extra item past its limit
+		if m[0].In != 6 || m[0].Out != 3 {
+			t.Errorf("evens: expected in 6 / out 3, got %+v", m[0])
+		}
+		if m[1].In != 3 || m[1].Out != 3 {
+			t.Errorf("label: expected in 3 / out 3, got %+v", m[1])
+		}
+		// the sleep happens inside label, so evens must not be charged for it
+		if m[1].Duration < 3*time.Millisecond || m[0].Duration >= m[1].Duration {
+			t.Errorf("unexpected durations: evens %v, label %v", m[0].Duration, m[1].Duration)
+		}
+	})
+
+	t.Run("Errors are blamed on the failing stage", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		s := FromSlice([]string{"1", "x", "3"}).Instrument(logger)
+
+		parsed := Stage(s, "parse", func(s Stream[string]) Stream[string] {
+			return MapErr(s, func(v string) (string, error) {
+				if _, err := strconv.Atoi(v); err != nil {
+					return "", errors.New("bad number")
+				}
+				return v, nil
+			})
+		})
+		trimmed := Stage(parsed, "trim", func(s Stream[string]) Stream[string] {
+			return Map(s, strings.TrimSpace)
+		})
+
+		if _, err := trimmed.Collect(); err == nil {
+			t.Fatal("expected error")
+		}
+		m := trimmed.Metrics()
+		if m[0].Errors != 1 || m[1].Errors != 0 {
+			t.Errorf("expected only parse to record the error, got %+v", m)
+		}
+
+		logs := buf.String()
+		for _, want := range []string{"stage start", "stage stop", "stage error", "bad number"} {
+			if !strings.Contains(logs, want) {
+				t.Errorf("expected log to contain %q, got:\n%s", want, logs)
+			}
+		}
+	})
+
+	t.Run("Disabled instrumentation records nothing", func(t *testing.T) {
+		s := Stage(FromSlice([]int{1, 2}), "noop", func(s Stream[int]) Stream[int] { return s })
+		if _, err := s.Collect(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s.Metrics() != nil {
+			t.Errorf("expected nil metrics, got %v", s.Metrics())
+		}
+	})
+
+	t.Run("Concurrent runs of stages wrapping lazy operators", func(t *testing.T) {
+		const n, runs = 100, 4
+		ops := []struct {
+			name    string
+			op      func(Stream[int]) Stream[int]
+			in, out int64 // -1: not deterministic
+		}{
+			{"cache", func(s Stream[int]) Stream[int] { return s.Cache() }, n, runs * n},
+			{"buffer", func(s Stream[int]) Stream[int] { return s.Buffer(4) }, runs * n, runs * n},
+			// branches are single-pass, so only the runs that get there first see items
+			{"tee", func(s Stream[int]) Stream[int] { return Merge(Tee(s, 2)...) }, n, 2 * n},
+		}
+		for _, tc := range ops {
+			t.Run(tc.name, func(t *testing.T) {
+				s := Stage(ints(n).Instrument(nil), tc.name, tc.op)
+
+				var wg sync.WaitGroup
+				for range runs {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if _, err := s.Collect(); err != nil {
+							t.Errorf("unexpected error: %v", err)
+						}
+					}()
+				}
+				wg.Wait()
+
+				m := s.Metrics()[0]
+				if m.In != tc.in || m.Out != tc.out {
+					t.Errorf("expected in %d / out %d, got %+v", tc.in, tc.out, m)
+				}
+				if m.Duration < 0 || m.Duration > time.Minute || m.Errors != 0 {
+					t.Errorf("unexpected metrics: %+v", m)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// PaceOption configures RateLimit and Throttle.
+type PaceOption func(*pacer)
+
+// WithContext stops the operator when ctx is cancelled. The context's error
+// trips the live wire, so the terminal returns it.
+func WithContext(ctx context.Context) PaceOption {
+	return func(p *pacer) { p.ctx = ctx }
+}
+
+// WithClock replaces the wall clock, e.g. with a FakeClock in tests.
+func WithClock(c Clock) PaceOption {
+	return func(p *pacer) { p.clock = c }
+}
+
+type pacer struct {
+	ctx   context.Context
+	clock Clock
+}
+
+func newPacer(opts []PaceOption) pacer {
+	p := pacer{ctx: context.Background(), clock: SystemClock}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
+}
+
+// wait blocks for d or until the context is cancelled.
+func (p pacer) wait(d time.Duration) error {
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	case <-p.clock.After(d):
+		return nil
+	}
+}
+
+// RateLimit caps the stream at rate items per second using a token bucket.
+// Up to burst items may pass back to back before the limit kicks in.
+// The operator blocks before each yield until a token is available.
+// rate must be positive.
+func (s Stream[T]) RateLimit(rate float64, burst int, opts ...PaceOption) Stream[T] {
+	if rate <= 0 {
+		panic(fmt.Sprintf("stream: rate must be positive, got %v", rate))
+	}
+	p := newPacer(opts)
+	burst = max(burst, 1)
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				tokens := float64(burst)
+				last := p.clock.Now()
+
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+
+					// refill for the time elapsed since the last item
+					now := p.clock.Now()
+					tokens = min(float64(burst), tokens+now.Sub(last).Seconds()*rate)
+					last = now
+
+					if tokens < 1 {
+						wait := time.Duration((1 - tokens) / rate * float64(time.Second))
+						if err := p.wait(wait); err != nil {
+							*errp = err
+							return
+						}
+						tokens = 1
+						last = p.clock.Now()
+					} else if err := p.ctx.Err(); err != nil {
+						*errp = err
+						return
+					}
+
+					tokens--
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Throttle spaces out yields so consecutive items are at least interval apart.
+// The first item passes immediately.
+func (s Stream[T]) Throttle(interval time.Duration, opts ...PaceOption) Stream[T] {
+	p := newPacer(opts)
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			seq := s.run(errp)
+			return func(yield func(T) bool) {
+				var last time.Time
+				first := true
+
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+
+					var wait time.Duration
+					if !first {
+						wait = interval - p.clock.Now().Sub(last)
+					}
+					if err := p.wait(wait); err != nil {
+						*errp = err
+						return
+					}
+
+					first = false
+					last = p.clock.Now()
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRateLimit(t *testing.T) {
+	t.Run("Burst passes then paces at rate", func(t *testing.T) {
+		clock := NewFakeClock(epoch)
+		got, err := FromSlice([]int{1, 2, 3, 4, 5}).
+			RateLimit(10, 2, WithClock(clock)).
+			Collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("unexpected items: %v", got)
+		}
+
+		// 2 items free from the burst, 3 more at 100ms each
+		if clock.Slept() != 300*time.Millisecond {
+			t.Errorf("expected 300ms of waiting, got %v", clock.Slept())
+		}
+	})
+
+	t.Run("Slow upstream refills the bucket", func(t *testing.T) {
+		clock := NewFakeClock(epoch)
+		src := New(func(yield func(int) bool) {
+			for i := range 4 {
+				clock.Advance(time.Second) // upstream work
+				if !yield(i) {
+					return
+				}
+			}
+		}, nil)
+
+		if _, err := src.RateLimit(2, 1, WithClock(clock)).Collect(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if clock.Slept() != 0 {
+			t.Errorf("expected no waiting, got %v", clock.Slept())
+		}
+	})
+
+	t.Run("Cancelled context trips the live wire", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		count := 0
+		err := FromSlice([]int{1, 2, 3}).
+			RateLimit(1, 1, WithClock(NewFakeClock(epoch)), WithContext(ctx)).
+			ForEach(func(int) {
+				count++
+				cancel()
+			})
+		if !errors.Is(err, context.Canceled) || count != 1 {
+			t.Errorf("expected context.Canceled after 1 item, got %v after %d", err, count)
+		}
+	})
+}
+
+func TestThrottle(t *testing.T) {
+	t.Run("Spaces out items", func(t *testing.T) {
+		clock := NewFakeClock(epoch)
+		var stamps []time.Duration
+		err := FromSlice([]int{1, 2, 3}).
+			Throttle(time.Second, WithClock(clock)).
+			ForEach(func(int) { stamps = append(stamps, clock.Now().Sub(epoch)) })
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []time.Duration{0, time.Second, 2 * time.Second}
+		if !slices.Equal(stamps, want) {
+			t.Errorf("expected %v, got %v", want, stamps)
+		}
+	})
+
+	t.Run("Early exit stops waiting", func(t *testing.T) {
+		clock := NewFakeClock(epoch)
+		v, err := FromSlice([]int{7, 8, 9}).Throttle(time.Minute, WithClock(clock)).First()
+		if err != nil || v != 7 || clock.Slept() != 0 {
+			t.Errorf("expected 7 with no wait, got %d after %v (err: %v)", v, clock.Slept(), err)
+		}
+	})
+
+	t.Run("Real clock honours cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := FromSlice([]int{1, 2}).Throttle(time.Hour, WithContext(ctx)).Collect()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+	})
+}
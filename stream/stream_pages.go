@@ -0,0 +1,117 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// PageOption configures FromPages and FromOffsetPages.
+type PageOption func(*pageConfig)
+
+type pageConfig struct {
+	prefetch bool
+}
+
+// Prefetch fetches the next page in the background while the current one is
+// being consumed, so the consumer doesn't wait on every page boundary.
+func Prefetch() PageOption {
+	return func(c *pageConfig) { c.prefetch = true }
+}
+
+// FromPages streams the items of a token-paginated API.
+// fetch is called with the empty token for the first page and must return the
+// page's items and the token of the next page, or "" after the last page.
+// Pages are fetched lazily, only once the previous page has been consumed.
+// A fetch error, including ctx being cancelled, trips the live wire.
+func FromPages[T any](ctx context.Context, fetch func(ctx context.Context, token string) ([]T, string, error), opts ...PageOption) Stream[T] {
+	return paginate(ctx, "", func(ctx context.Context, token string) (page[T, string], error) {
+		items, next, err := fetch(ctx, token)
+		return page[T, string]{items: items, next: next, more: next != ""}, err
+	}, opts)
+}
+
+// FromOffsetPages streams the items of an offset/limit-paginated API, such as
+// a SQL query with OFFSET and LIMIT. fetch is called with increasing offsets
+// and a limit of pageSize; a page with fewer than pageSize items is the last.
+// pageSize must be positive.
+func FromOffsetPages[T any](ctx context.Context, pageSize int, fetch func(ctx context.Context, offset, limit int) ([]T, error), opts ...PageOption) Stream[T] {
+	if pageSize < 1 {
+		panic(fmt.Sprintf("stream: page size must be positive, got %d", pageSize))
+	}
+	return paginate(ctx, 0, func(ctx context.Context, offset int) (page[T, int], error) {
+		items, err := fetch(ctx, offset, pageSize)
+		return page[T, int]{items: items, next: offset + len(items), more: len(items) == pageSize}, err
+	}, opts)
+}
+
+// page is one fetched page and the cursor (token or offset) of the next.
+type page[T, C any] struct {
+	items []T
+	next  C
+	more  bool
+	err   error
+}
+
+// paginate drives any cursor-based fetch. Each run starts over from first.
+func paginate[T, C any](ctx context.Context, first C, fetch func(context.Context, C) (page[T, C], error), opts []PageOption) Stream[T] {
+	var cfg pageConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	get := func(ctx context.Context, c C) page[T, C] {
+		if err := ctx.Err(); err != nil {
+			return page[T, C]{err: err}
+		}
+		p, err := fetch(ctx, c)
+		p.err = err
+		return p
+	}
+
+	return Stream[T]{
+		run: func(errp *error) iter.Seq[T] {
+			return func(yield func(T) bool) {
+				ctx, cancel := context.WithCancel(ctx)
+				var ahead chan page[T, C]
+				defer func() {
+					// don't let a prefetch outlive the run
+					cancel()
+					if ahead != nil {
+						<-ahead
+					}
+				}()
+
+				cur := get(ctx, first)
+				for {
+					if cur.err != nil {
+						*errp = cur.err
+						return
+					}
+					if cfg.prefetch && cur.more {
+						ahead = make(chan page[T, C], 1)
+						go func(ch chan<- page[T, C], c C) {
+							ch <- get(ctx, c)
+						}(ahead, cur.next)
+					}
+
+					for _, v := range cur.items {
+						if !yield(v) {
+							return
+						}
+					}
+					if !cur.more {
+						return
+					}
+
+					if ahead != nil {
+						cur = <-ahead
+						ahead = nil
+					} else {
+						cur = get(ctx, cur.next)
+					}
+				}
+			}
+		},
+	}
+}
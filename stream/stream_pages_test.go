@@ -0,0 +1,134 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// tokenAPI serves data in pages of size, using the next offset as the token.
+type tokenAPI struct {
+	mu    sync.Mutex
+	data  []int
+	size  int
+	calls []string
+	fail  string // token that returns an error
+}
+
+func (a *tokenAPI) fetch(_ context.Context, token string) ([]int, string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, token)
+	if token == a.fail && a.fail != "" {
+		return nil, "", errors.New("fetch failed")
+	}
+	start := 0
+	if token != "" {
+		start, _ = strconv.Atoi(token)
+	}
+	end := min(start+a.size, len(a.data))
+	next := ""
+	if end < len(a.data) {
+		next = strconv.Itoa(end)
+	}
+	return a.data[start:end], next, nil
+}
+
+func (a *tokenAPI) fetched() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.calls)
+}
+
+func TestFromPages(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Yields Every Page", func(t *testing.T) {
+		api := &tokenAPI{data: []int{1, 2, 3, 4, 5}, size: 2}
+		got, err := FromPages(ctx, api.fetch).Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("expected [1 2 3 4 5], got %v (err: %v)", got, err)
+		}
+		if calls := api.fetched(); !slices.Equal(calls, []string{"", "2", "4"}) {
+			t.Errorf("unexpected fetches %q", calls)
+		}
+	})
+
+	t.Run("Fetches Lazily", func(t *testing.T) {
+		api := &tokenAPI{data: []int{1, 2, 3, 4, 5}, size: 2}
+		first, err := FromPages(ctx, api.fetch).First()
+		if err != nil || first != 1 {
+			t.Fatalf("expected 1, got %d (err: %v)", first, err)
+		}
+		if calls := api.fetched(); len(calls) != 1 {
+			t.Errorf("expected a single fetch, got %q", calls)
+		}
+	})
+
+	t.Run("Prefetch", func(t *testing.T) {
+		api := &tokenAPI{data: []int{1, 2, 3, 4, 5}, size: 2}
+		s := FromPages(ctx, api.fetch, Prefetch())
+
+		got, err := s.Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("expected [1 2 3 4 5], got %v (err: %v)", got, err)
+		}
+
+		// stopping on the first page still waits for the page fetched ahead
+		api.calls = nil
+		if _, err := s.First(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls := api.fetched(); len(calls) > 2 {
+			t.Errorf("expected at most one page ahead, got %q", calls)
+		}
+	})
+
+	t.Run("Fetch Error Trips The Wire", func(t *testing.T) {
+		for _, opts := range [][]PageOption{nil, {Prefetch()}} {
+			api := &tokenAPI{data: []int{1, 2, 3, 4, 5}, size: 2, fail: "2"}
+			var seen []int
+			err := FromPages(ctx, api.fetch, opts...).ForEach(func(n int) { seen = append(seen, n) })
+			if err == nil || err.Error() != "fetch failed" {
+				t.Errorf("expected fetch error, got %v", err)
+			}
+			if !slices.Equal(seen, []int{1, 2}) {
+				t.Errorf("expected the first page only, got %v", seen)
+			}
+		}
+	})
+
+	t.Run("Cancelled Context", func(t *testing.T) {
+		api := &tokenAPI{data: []int{1, 2, 3}, size: 2}
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := FromPages(cancelled, api.fetch).Collect()
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if calls := api.fetched(); len(calls) != 0 {
+			t.Errorf("expected no fetches, got %q", calls)
+		}
+	})
+}
+
+func TestFromOffsetPages(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+	var offsets []int
+	fetch := func(_ context.Context, offset, limit int) ([]int, error) {
+		offsets = append(offsets, offset)
+		return data[offset:min(offset+limit, len(data))], nil
+	}
+
+	got, err := FromOffsetPages(context.Background(), 3, fetch).Collect()
+	if err != nil || !slices.Equal(got, data) {
+		t.Errorf("expected %v, got %v (err: %v)", data, got, err)
+	}
+	// an exactly full last page costs one extra, empty fetch
+	if !slices.Equal(offsets, []int{0, 3, 6}) {
+		t.Errorf("unexpected offsets %v", offsets)
+	}
+}
@@ -0,0 +1,167 @@
+package stream
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// parBatch is how many consecutive elements a ParFold worker folds at a time.
+const parBatch = 256
+
+// ParFold is a parallel Fold. The source is read sequentially, cut into
+// batches of consecutive elements, and the batches are folded concurrently by
+// up to workers goroutines, each starting from a fresh init(). The partial
+// results are then merged with combine in source order, so the result equals
+// the sequential Fold whenever combine is associative and init() is its
+// identity; combine need not be commutative.
+//
+// workers <= 0 means runtime.GOMAXPROCS(0). fold and combine must not share
+// unsynchronized state across calls. Partial results are combined as soon as
+// every batch before them is done, and reading pauses while too many are
+// waiting on an earlier batch, so at most 2*workers partials are held in
+// memory however long the stream. As with Fold, an error from the stream stops reading and is
+// returned with a fresh init(). A panic in fold, or in the source, is
+// re-raised in the caller once every worker has stopped.
+func ParFold[T, A any](s Stream[T], workers int, init func() A, fold func(A, T) A, combine func(A, A) A) (A, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	seq, errp := s.open()
+
+	type batch struct {
+		index int
+		items []T
+	}
+	batches := make(chan batch, workers)
+	stop := make(chan struct{})
+
+	var (
+		mu       sync.Mutex
+		cond     = sync.NewCond(&mu)
+		acc      = init()
+		combined int           // batches [0, combined) are folded into acc
+		pending  = map[int]A{} // finished batches waiting for an earlier one
+		panicked any
+		wg       sync.WaitGroup
+	)
+	window := 2 * workers // max batches read ahead of combined
+
+	// finish hands in a batch's partial and combines the finished prefix in
+	// source order, so combine need not be commutative.
+	finish := func(index int, part A) {
+		mu.Lock()
+		defer mu.Unlock()
+		defer cond.Broadcast()
+		pending[index] = part
+		for {
+			p, ok := pending[combined]
+			if !ok {
+				return
+			}
+			delete(pending, combined)
+			acc = combine(acc, p)
+			combined++
+		}
+	}
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					mu.Lock()
+					defer mu.Unlock()
+					if panicked == nil {
+						panicked = r
+						close(stop)
+						cond.Broadcast()
+					}
+				}
+			}()
+			for b := range batches {
+				part := init()
+				for _, v := range b.items {
+					part = fold(part, v)
+				}
+				finish(b.index, part)
+			}
+		}()
+	}
+
+	// the calling goroutine is the only reader of the source
+	send := func(b batch) bool {
+		mu.Lock()
+		for b.index-combined >= window && panicked == nil {
+			cond.Wait()
+		}
+		mu.Unlock()
+		select {
+		case batches <- b:
+			return true
+		case <-stop:
+			return false
+		}
+	}
+	func() {
+		// the workers must stop even if the source panics
+		defer func() {
+			close(batches)
+			wg.Wait()
+		}()
+		cur := batch{items: make([]T, 0, parBatch)}
+		for v := range seq {
+			if *errp != nil {
+				break
+			}
+			cur.items = append(cur.items, v)
+			if len(cur.items) == parBatch {
+				if !send(cur) {
+					break
+				}
+				cur = batch{index: cur.index + 1, items: make([]T, 0, parBatch)}
+			}
+		}
+		if *errp == nil && len(cur.items) > 0 {
+			send(cur)
+		}
+	}()
+
+	if panicked != nil {
+		panic(panicked)
+	}
+	if err := *errp; err != nil {
+		return init(), err
+	}
+	return acc, nil
+}
+
+// ParReduce is a parallel Reduce. fn must be associative; like Reduce, an
+// empty stream is an error.
+func ParReduce[T any](s Stream[T], workers int, fn func(T, T) T) (T, error) {
+	type partial struct {
+		v  T
+		ok bool
+	}
+	merge := func(a, b partial) partial {
+		switch {
+		case !a.ok:
+			return b
+		case !b.ok:
+			return a
+		}
+		return partial{v: fn(a.v, b.v), ok: true}
+	}
+	res, err := ParFold(s, workers,
+		func() partial { return partial{} },
+		func(acc partial, v T) partial { return merge(acc, partial{v: v, ok: true}) },
+		merge,
+	)
+	if err != nil {
+		return res.v, err
+	}
+	if !res.ok {
+		return res.v, fmt.Errorf("cannot reduce empty stream")
+	}
+	return res.v, nil
+}
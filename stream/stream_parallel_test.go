@@ -0,0 +1,176 @@
+package stream
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParFold(t *testing.T) {
+	t.Run("Matches Sequential Fold", func(t *testing.T) {
+		for _, workers := range []int{0, 1, 3, 8} {
+			sum, err := ParFold(ints(10_000), workers,
+				func() int { return 0 },
+				func(acc, v int) int { return acc + v },
+				func(a, b int) int { return a + b },
+			)
+			if err != nil || sum != 49_995_000 {
+				t.Errorf("workers=%d: expected 49995000, got %d (err: %v)", workers, sum, err)
+			}
+		}
+	})
+
+	t.Run("Keeps Order For Non-Commutative Combine", func(t *testing.T) {
+		// string concatenation is associative but not commutative
+		concat := func() *strings.Builder { return &strings.Builder{} }
+		got, err := ParFold(ints(1000), 4,
+			concat,
+			func(sb *strings.Builder, v int) *strings.Builder {
+				sb.WriteString(strconv.Itoa(v))
+				sb.WriteByte(',')
+				return sb
+			},
+			func(a, b *strings.Builder) *strings.Builder {
+				a.WriteString(b.String())
+				return a
+			},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var want strings.Builder
+		for i := range 1000 {
+			want.WriteString(strconv.Itoa(i) + ",")
+		}
+		if got.String() != want.String() {
+			t.Error("expected the parallel result to match the sequential order")
+		}
+	})
+
+	t.Run("Empty Stream Returns Init", func(t *testing.T) {
+		got, err := ParFold(ints(0), 4,
+			func() int { return 0 },
+			func(acc, v int) int { return acc + v },
+			func(a, b int) int { return a + b },
+		)
+		if err != nil || got != 0 {
+			t.Errorf("expected 0, got %d (err: %v)", got, err)
+		}
+	})
+
+	t.Run("Stream Error", func(t *testing.T) {
+		boom := errors.New("boom")
+		s := MapErr(ints(5000), func(n int) (int, error) {
+			if n == 3000 {
+				return 0, boom
+			}
+			return n, nil
+		})
+		got, err := ParFold(s, 4,
+			func() int { return -1 },
+			func(acc, v int) int { return acc + v },
+			func(a, b int) int { return a + b },
+		)
+		if !errors.Is(err, boom) || got != -1 {
+			t.Errorf("expected boom and init(), got %d (err: %v)", got, err)
+		}
+	})
+
+	t.Run("Combines Partials Eagerly", func(t *testing.T) {
+		// count the accumulators alive at once: init makes one, combine
+		// consumes its second argument
+		var mu sync.Mutex
+		live, peak := 0, 0
+		const workers = 4
+		got, err := ParFold(ints(parBatch*200), workers,
+			func() int {
+				mu.Lock()
+				defer mu.Unlock()
+				live++
+				peak = max(peak, live)
+				return 0
+			},
+			func(acc, v int) int { return acc + v },
+			func(a, b int) int {
+				mu.Lock()
+				defer mu.Unlock()
+				live--
+				return a + b
+			},
+		)
+		n := parBatch * 200
+		if err != nil || got != n*(n-1)/2 {
+			t.Fatalf("expected %d, got %d (err: %v)", n*(n-1)/2, got, err)
+		}
+		// far fewer than the 200 batches, however the workers are scheduled:
+		// the batches in flight plus the combined total
+		if peak > 2*workers+1 {
+			t.Errorf("expected at most %d live accumulators, got %d", 2*workers+1, peak)
+		}
+	})
+
+	t.Run("Panic In Fold", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "bad item" {
+				t.Errorf("expected the fold panic, got %v", r)
+			}
+		}()
+		ParFold(ints(5000), 4,
+			func() int { return 0 },
+			func(acc, v int) int {
+				if v == 2500 {
+					panic("bad item")
+				}
+				return acc + v
+			},
+			func(a, b int) int { return a + b },
+		)
+		t.Error("expected ParFold to panic")
+	})
+
+	t.Run("Panic In Source Stops Workers", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		src := FromSeq(func(yield func(int) bool) {
+			for i := range 1000 {
+				if !yield(i) {
+					return
+				}
+			}
+			panic("read failure")
+		})
+
+		func() {
+			defer func() {
+				if r := recover(); r != "read failure" {
+					t.Errorf("expected the source panic, got %v", r)
+				}
+			}()
+			ParFold(src, 4,
+				func() int { return 0 },
+				func(acc, v int) int { return acc + v },
+				func(a, b int) int { return a + b },
+			)
+		}()
+
+		// the workers are waited for before the panic leaves ParFold
+		if after := runtime.NumGoroutine(); after > before {
+			t.Errorf("expected no leaked workers, had %d goroutines, now %d", before, after)
+		}
+	})
+}
+
+func TestParReduce(t *testing.T) {
+	got, err := ParReduce(ints(10_000), 4, func(a, b int) int { return max(a, b) })
+	if err != nil || got != 9999 {
+		t.Errorf("expected 9999, got %d (err: %v)", got, err)
+	}
+
+	_, err = ParReduce(ints(0), 4, func(a, b int) int { return a + b })
+	if err == nil || err.Error() != "cannot reduce empty stream" {
+		t.Errorf("expected the empty stream error, got %v", err)
+	}
+}
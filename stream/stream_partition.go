@@ -0,0 +1,32 @@
+package stream
+
+import (
+	"fmt"
+
+	"github.com/wesleylin/basin/internal/hash"
+)
+
+// PartitionBy splits s into n streams by key hash, Kafka style: every element
+// whose key hashes alike goes to the same partition, in source order, so each
+// partition can be processed by its own worker while per-key order is kept.
+// Equal keys always land in the same partition, including struct keys.
+//
+// Buffering, early stop and error semantics are those of Partition: the
+// partitions share one pass over s, and the first error anywhere stops them all.
+// n must be positive.
+func PartitionBy[T any, K comparable](s Stream[T], n int, keyFn func(T) K) []Stream[T] {
+	return PartitionByBounded(s, n, 0, keyFn)
+}
+
+// PartitionByBounded is PartitionBy with each partition's buffer capped at
+// size items, giving backpressure: a full partition holds back the source until
+// its worker catches up. The partitions must be consumed concurrently.
+// A size <= 0 means unbounded.
+func PartitionByBounded[T any, K comparable](s Stream[T], n, size int, keyFn func(T) K) []Stream[T] {
+	if n < 1 {
+		panic(fmt.Sprintf("stream: partition count must be positive, got %d", n))
+	}
+	return newSplitter(s, n, size, func(v T) int {
+		return int(hash.Maphash(keyFn(v)) % uint64(n))
+	}).branches()
+}
@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+type event struct {
+	user string
+	seq  int
+}
+
+func events(users, perUser int) []event {
+	var out []event
+	for i := range perUser {
+		for u := range users {
+			out = append(out, event{user: fmt.Sprintf("user-%d", u), seq: i})
+		}
+	}
+	return out
+}
+
+func TestPartitionBy(t *testing.T) {
+	byUser := func(e event) string { return e.user }
+
+	t.Run("Same Key Same Partition In Order", func(t *testing.T) {
+		parts := PartitionBy(FromSlice(events(20, 5)), 4, byUser)
+		if len(parts) != 4 {
+			t.Fatalf("expected 4 partitions, got %d", len(parts))
+		}
+
+		owner := make(map[string]int)
+		total := 0
+		for i, p := range parts {
+			got, err := p.Collect()
+			if err != nil {
+				t.Fatalf("partition %d: unexpected error: %v", i, err)
+			}
+			next := make(map[string]int)
+			for _, e := range got {
+				if o, ok := owner[e.user]; ok && o != i {
+					t.Errorf("%s seen in partitions %d and %d", e.user, o, i)
+				}
+				owner[e.user] = i
+				if e.seq != next[e.user] {
+					t.Errorf("%s: expected seq %d, got %d", e.user, next[e.user], e.seq)
+				}
+				next[e.user]++
+			}
+			total += len(got)
+		}
+		if total != 100 {
+			t.Errorf("expected 100 events across partitions, got %d", total)
+		}
+	})
+
+	t.Run("Equal Struct Keys Same Partition", func(t *testing.T) {
+		type key struct {
+			Tenant string
+			ID     int
+		}
+		keys := make([]key, 40)
+		for i := range keys {
+			// separately allocated strings, so equal keys differ in memory
+			keys[i] = key{Tenant: string([]byte("acme")), ID: 1}
+		}
+
+		sizes := make([]int, 0, 4)
+		for _, p := range PartitionBy(FromSlice(keys), 4, func(k key) key { return k }) {
+			n, err := p.Count()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n > 0 {
+				sizes = append(sizes, n)
+			}
+		}
+		if !slices.Equal(sizes, []int{40}) {
+			t.Errorf("expected every key in one partition, got sizes %v", sizes)
+		}
+	})
+
+	t.Run("Bounded Concurrent Workers", func(t *testing.T) {
+		parts := PartitionByBounded(FromSlice(events(50, 20)), 8, 2, byUser)
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		seen := 0
+		for _, p := range parts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				n, err := p.Count()
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				mu.Lock()
+				seen += n
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		if seen != 1000 {
+			t.Errorf("expected 1000 events, got %d", seen)
+		}
+	})
+
+	t.Run("Error Stops Every Partition", func(t *testing.T) {
+		boom := errors.New("boom")
+		s := MapErr(FromSlice(events(10, 10)), func(e event) (event, error) {
+			if e.seq == 5 {
+				return e, boom
+			}
+			return e, nil
+		})
+		for i, p := range PartitionBy(s, 3, byUser) {
+			if _, err := p.Collect(); !errors.Is(err, boom) {
+				t.Errorf("partition %d: expected boom, got %v", i, err)
+			}
+		}
+	})
+
+	t.Run("Single Partition Keeps Everything", func(t *testing.T) {
+		got, err := PartitionBy(FromSlice([]int{3, 1, 2}), 1, func(n int) int { return n })[0].Collect()
+		if err != nil || !slices.Equal(got, []int{3, 1, 2}) {
+			t.Errorf("expected [3 1 2], got %v (err: %v)", got, err)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package stream
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// Sampling takes a *rand.Rand so results are reproducible: seed it, e.g. with
+// rand.New(rand.NewPCG(1, 2)), to get the same sample on every run.
+// A nil rng uses a randomly seeded generator, a fresh one for each run of a
+// sampled stream. A *rand.Rand passed in is shared by every run and is not
+// safe for concurrent use, so don't run such a stream from several goroutines
+// at once.
+
+func orRandom(rng *rand.Rand) *rand.Rand {
+	if rng == nil {
+		return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return rng
+}
+
+// Reservoir returns a uniform random sample of k elements of s, or all of
+// them if s has fewer than k. It uses Algorithm L, which skips over elements
+// that won't be picked instead of drawing a random number for each one, so it
+// stays fast on long streams. The sample is in no particular order.
+func Reservoir[T any](s Stream[T], k int, rng *rand.Rand) ([]T, error) {
+	r := newReservoir[T](k, orRandom(rng))
+	if err := s.ForEach(r.add); err != nil {
+		return nil, err
+	}
+	return r.items, nil
+}
+
+// reservoir is the state of Algorithm L.
+type reservoir[T any] struct {
+	rng   *rand.Rand
+	k     int
+	items []T
+	seen  int     // elements offered so far
+	next  int     // 1-based position of the next element to pick
+	w     float64 // largest of k uniform draws, shrinking as the stream grows
+}
+
+func newReservoir[T any](k int, rng *rand.Rand) *reservoir[T] {
+	return &reservoir[T]{rng: rng, k: max(k, 0)}
+}
+
+// uniform returns a draw from (0, 1], safe to take the log of.
+func (r *reservoir[T]) uniform() float64 {
+	return 1 - r.rng.Float64()
+}
+
+// skip moves next past the elements that won't be picked.
+func (r *reservoir[T]) skip() {
+	r.w *= math.Exp(math.Log(r.uniform()) / float64(r.k))
+	gap := math.Floor(math.Log(r.uniform()) / math.Log(1-r.w))
+	// a gap that is huge, or NaN once w underflows, means nothing else will
+	// realistically be picked
+	if math.IsNaN(gap) || gap > math.MaxInt32 {
+		gap = math.MaxInt32
+	}
+	r.next += int(gap) + 1
+}
+
+func (r *reservoir[T]) add(v T) {
+	r.seen++
+	switch {
+	case len(r.items) < r.k:
+		r.items = append(r.items, v)
+		if len(r.items) == r.k {
+			r.w = 1
+			r.next = r.seen
+			r.skip()
+		}
+	case r.k > 0 && r.seen == r.next:
+		r.items[r.rng.IntN(r.k)] = v
+		r.skip()
+	}
+}
+
+// Sample keeps each element independently with probability p (Bernoulli sampling).
+// p must be in [0, 1].
+func (s Stream[T]) Sample(p float64, rng *rand.Rand) Stream[T] {
+	if p < 0 || p > 1 {
+		panic(fmt.Sprintf("stream: sample probability must be in [0, 1], got %v", p))
+	}
+	return Stream[T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[T] {
+			rng := orRandom(rng)
+			return s.Filter(func(T) bool {
+				return rng.Float64() < p
+			}).run(errp)
+		},
+	}
+}
+
+// StratifiedSample draws a uniform sample of up to perKey elements from each
+// group of elements sharing a key, so rare keys are represented as well as
+// common ones. Groups are yielded in first-seen key order once the whole
+// stream has been consumed; nothing is yielded if the stream fails.
+func StratifiedSample[T any, K comparable](s Stream[T], keyFn func(T) K, perKey int, rng *rand.Rand) Stream2[K, []T] {
+	return Stream2[K, []T]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[K, []T] {
+			rng := orRandom(rng)
+			seq := s.run(errp)
+			return func(yield func(K, []T) bool) {
+				index := make(map[K]int)
+				var keys []K
+				var samples []*reservoir[T]
+
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+					k := keyFn(v)
+					i, ok := index[k]
+					if !ok {
+						i = len(keys)
+						index[k] = i
+						keys = append(keys, k)
+						samples = append(samples, newReservoir[T](perKey, rng))
+					}
+					samples[i].add(v)
+				}
+
+				if *errp != nil {
+					return
+				}
+				for i, k := range keys {
+					if !yield(k, samples[i].items) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// Shuffle collects the stream and returns its elements in random order.
+func (s Stream[T]) Shuffle(rng *rand.Rand) ([]T, error) {
+	items, err := s.Collect()
+	if err != nil {
+		return nil, err
+	}
+	rng = orRandom(rng)
+	rng.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+	return items, nil
+}
@@ -0,0 +1,161 @@
+package stream
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func seeded() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+// ints streams 0..n-1.
+func ints(n int) Stream[int] {
+	return FromSeq(func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+}
+
+func TestReservoir(t *testing.T) {
+	t.Run("Reproducible", func(t *testing.T) {
+		s := ints(10_000)
+		a, err := Reservoir(s, 10, seeded())
+		if err != nil || len(a) != 10 {
+			t.Fatalf("expected 10 items, got %v (err: %v)", a, err)
+		}
+		b, _ := Reservoir(s, 10, seeded())
+		if !slices.Equal(a, b) {
+			t.Errorf("expected the same sample for the same seed, got %v and %v", a, b)
+		}
+	})
+
+	t.Run("Short Stream", func(t *testing.T) {
+		got, err := Reservoir(FromSlice([]int{1, 2, 3}), 5, seeded())
+		if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v (err: %v)", got, err)
+		}
+		got, _ = Reservoir(FromSlice([]int{1, 2, 3}), 0, seeded())
+		if len(got) != 0 {
+			t.Errorf("expected an empty sample, got %v", got)
+		}
+	})
+
+	t.Run("Uniform", func(t *testing.T) {
+		// each of 20 elements should land in a sample of 5 about 1/4 of the time
+		const trials = 20_000
+		counts := make([]int, 20)
+		rng := seeded()
+		for range trials {
+			sample, _ := Reservoir(ints(20), 5, rng)
+			for _, v := range sample {
+				counts[v]++
+			}
+		}
+		for v, c := range counts {
+			if frac := float64(c) / trials; frac < 0.22 || frac > 0.28 {
+				t.Errorf("element %d picked %.3f of the time, want ~0.25", v, frac)
+			}
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		boom := errors.New("boom")
+		s := MapErr(ints(10), func(n int) (int, error) {
+			if n == 5 {
+				return 0, boom
+			}
+			return n, nil
+		})
+		if _, err := Reservoir(s, 3, seeded()); !errors.Is(err, boom) {
+			t.Errorf("expected boom, got %v", err)
+		}
+	})
+}
+
+func TestSample(t *testing.T) {
+	n, err := ints(10_000).Sample(0.1, seeded()).Count()
+	if err != nil || n < 900 || n > 1100 {
+		t.Errorf("expected about 1000 items, got %d (err: %v)", n, err)
+	}
+
+	a, _ := ints(100).Sample(0.5, seeded()).Collect()
+	b, _ := ints(100).Sample(0.5, seeded()).Collect()
+	if !slices.Equal(a, b) {
+		t.Error("expected the same sample for the same seed")
+	}
+
+	if n, _ := ints(100).Sample(0, nil).Count(); n != 0 {
+		t.Errorf("expected no items for p=0, got %d", n)
+	}
+
+	t.Run("Concurrent Runs With Nil Rng", func(t *testing.T) {
+		// each run gets its own generator, so this is race free
+		sampled := ints(1000).Sample(0.5, nil)
+		strata := StratifiedSample(ints(1000), func(n int) int { return n % 3 }, 5, nil)
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if _, err := sampled.Count(); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				if _, err := strata.Collect(); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestStratifiedSample(t *testing.T) {
+	// 1000 evens and 10 odds
+	s := FromSlice(append(make([]int, 1000), 1, 3, 5, 7, 9, 11, 13, 15, 17, 19))
+	parity := func(n int) int { return n % 2 }
+
+	var keys []int
+	err := StratifiedSample(s, parity, 3, seeded()).ForEach(func(k int, sample []int) {
+		keys = append(keys, k)
+		if len(sample) != 3 {
+			t.Errorf("key %d: expected 3 items, got %v", k, sample)
+		}
+		for _, v := range sample {
+			if parity(v) != k {
+				t.Errorf("key %d: unexpected item %d", k, v)
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(keys, []int{0, 1}) {
+		t.Errorf("expected keys in first-seen order, got %v", keys)
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	a, err := ints(20).Shuffle(seeded())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := ints(20).Shuffle(seeded())
+	if !slices.Equal(a, b) {
+		t.Errorf("expected the same order for the same seed, got %v and %v", a, b)
+	}
+	sorted := slices.Sorted(slices.Values(a))
+	want, _ := ints(20).Collect()
+	if !slices.Equal(sorted, want) {
+		t.Errorf("expected a permutation of 0..19, got %v", a)
+	}
+}
@@ -0,0 +1,218 @@
+package stream
+
+import (
+	"iter"
+	"sync"
+)
+
+// Tee and Partition split one pass over a source into several branch streams.
+//
+// Consumption: the source is pulled on demand by whichever branch needs the
+// next item; items destined for other branches are parked in per-branch queues.
+//   - Tee/Partition use unbounded queues, so branches may be consumed one after
+//     another (full buffering) or concurrently.
+//   - TeeBounded/PartitionBounded cap each queue. A branch that pulls an item
+//     for a full queue blocks until that queue drains (backpressure), so the
+//     branches MUST be consumed concurrently, e.g. one goroutine each.
+//
+// Early stop: a branch that stops early (break, First, Take...) detaches.
+// Its queue is dropped and it no longer receives items or holds back the
+// others. The source is stopped once every branch has detached, so run or
+// drain every branch: a branch that is never run keeps the source paused,
+// and its goroutine alive, until all the branches are garbage collected.
+//
+// Errors: each branch has its own error slot. The first error seen anywhere,
+// either from the source or from an operator chained on one branch such as
+// MapErr, stops every branch, and every branch's terminal reports it.
+// A panic in the source is re-raised in the branch that was pulling, and
+// every other branch fails with an error instead of ending short.
+//
+// Unlike other streams, branches are single-pass: they share one run of the
+// source, so running a branch a second time yields nothing new.
+
+// Tee returns n streams that each yield every item of s.
+func Tee[T any](s Stream[T], n int) []Stream[T] {
+	return TeeBounded(s, n, 0)
+}
+
+// TeeBounded is Tee with each branch's buffer capped at size items.
+// The branches must be consumed concurrently. A size <= 0 means unbounded.
+func TeeBounded[T any](s Stream[T], n, size int) []Stream[T] {
+	return newSplitter(s, n, size, nil).branches()
+}
+
+// Partition splits s into the items that match pred and the items that don't.
+func Partition[T any](s Stream[T], pred func(T) bool) (Stream[T], Stream[T]) {
+	return PartitionBounded(s, pred, 0)
+}
+
+// PartitionBounded is Partition with each branch's buffer capped at size items.
+// The branches must be consumed concurrently. A size <= 0 means unbounded.
+func PartitionBounded[T any](s Stream[T], pred func(T) bool, size int) (Stream[T], Stream[T]) {
+	out := newSplitter(s, 2, size, func(v T) int {
+		if pred(v) {
+			return 0
+		}
+		return 1
+	}).branches()
+	return out[0], out[1]
+}
+
+// splitter is the shared state behind a set of branches.
+type splitter[T any] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	src   *puller[T]
+	route func(T) int // nil means every branch gets every item
+	limit int         // max queued items per branch, 0 = unbounded
+
+	queues [][]T
+	active []bool
+	live   int
+	err    error // the first error from a branch
+}
+
+func newSplitter[T any](s Stream[T], n, limit int, route func(T) int) *splitter[T] {
+	sp := &splitter[T]{
+		route:  route,
+		limit:  max(limit, 0),
+		queues: make([][]T, n),
+		active: make([]bool, n),
+		live:   n,
+	}
+	sp.cond = sync.NewCond(&sp.mu)
+	sp.src = newPuller(s, &sp.mu, sp.cond)
+	for i := range n {
+		sp.active[i] = true
+	}
+	return sp
+}
+
+func (sp *splitter[T]) branches() []Stream[T] {
+	out := make([]Stream[T], len(sp.queues))
+	for i := range out {
+		out[i] = Stream[T]{
+			metrics: sp.src.src.metrics,
+			run: func(errp *error) iter.Seq[T] {
+				return func(yield func(T) bool) {
+					defer sp.detach(i, errp)
+					for {
+						v, ok := sp.take(i, errp)
+						if !ok || !yield(v) {
+							return
+						}
+					}
+				}
+			},
+		}
+	}
+	return out
+}
+
+// take returns the next item for branch i, pulling from the source when its queue is empty.
+// errp is the live wire of the branch's current run.
+func (sp *splitter[T]) take(i int, errp *error) (T, bool) {
+	var zero T
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	for {
+		// Failures win over buffered items: every branch stops on the first error.
+		if err := sp.failure(); err != nil {
+			if *errp == nil {
+				*errp = err
+			}
+			return zero, false
+		}
+		if !sp.active[i] {
+			return zero, false
+		}
+
+		if q := sp.queues[i]; len(q) > 0 {
+			v := q[0]
+			q[0] = zero // release the reference for the GC
+			sp.queues[i] = q[1:]
+			sp.cond.Broadcast()
+			return v, true
+		}
+		if sp.src.done {
+			return zero, false
+		}
+		if sp.src.pulling {
+			sp.cond.Wait()
+			continue
+		}
+
+		v, ok := sp.src.pull()
+		if !ok {
+			continue
+		}
+
+		mine := sp.deliver(i, v)
+		sp.src.release()
+		if mine {
+			return v, true
+		}
+	}
+}
+
+// deliver hands v to its destination branches, blocking while a bounded
+// queue is full. It reports whether branch i itself should receive v.
+// Callers must hold sp.mu and own the pulling flag.
+func (sp *splitter[T]) deliver(i int, v T) bool {
+	if sp.route != nil {
+		j := sp.route(v)
+		if j == i {
+			return true
+		}
+		sp.enqueue(j, v)
+		return false
+	}
+
+	for j := range sp.queues {
+		if j != i {
+			sp.enqueue(j, v)
+		}
+	}
+	return true
+}
+
+func (sp *splitter[T]) enqueue(j int, v T) {
+	for sp.limit > 0 && sp.active[j] && sp.failure() == nil && len(sp.queues[j]) >= sp.limit {
+		sp.cond.Wait()
+	}
+	// detached branches drop their items
+	if sp.active[j] && sp.failure() == nil {
+		sp.queues[j] = append(sp.queues[j], v)
+	}
+}
+
+// detach marks branch i as finished, publishing its error to the other branches.
+func (sp *splitter[T]) detach(i int, errp *error) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if !sp.active[i] {
+		return
+	}
+	if *errp != nil && sp.err == nil {
+		sp.err = *errp
+	}
+	sp.active[i] = false
+	sp.queues[i] = nil
+	sp.live--
+	if sp.live == 0 {
+		sp.src.close()
+	}
+	sp.cond.Broadcast()
+}
+
+// failure returns the error that stops every branch: the first one from a
+// branch, or else the source's. Callers must hold sp.mu.
+func (sp *splitter[T]) failure() error {
+	if sp.err != nil {
+		return sp.err
+	}
+	return sp.src.err
+}
@@ -0,0 +1,249 @@
+package stream
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTee(t *testing.T) {
+	t.Run("Sequential Consumption Buffers Fully", func(t *testing.T) {
+		branches := Tee(FromSlice([]int{1, 2, 3}), 2)
+
+		sum, err := Sum(branches[0])
+		if err != nil || sum != 6 {
+			t.Fatalf("expected 6, got %d (err: %v)", sum, err)
+		}
+		got, err := branches[1].Collect()
+		if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v (err: %v)", got, err)
+		}
+	})
+
+	t.Run("Single Pass Over Source", func(t *testing.T) {
+		pulls := 0
+		src := New(func(yield func(int) bool) {
+			for i := range 5 {
+				pulls++
+				if !yield(i) {
+					return
+				}
+			}
+		}, nil)
+
+		branches := Tee(src, 3)
+		for _, b := range branches {
+			if n, _ := b.Count(); n != 5 {
+				t.Errorf("expected 5 items per branch, got %d", n)
+			}
+		}
+		if pulls != 5 {
+			t.Errorf("expected source to be pulled 5 times, got %d", pulls)
+		}
+	})
+
+	t.Run("Bounded Concurrent Consumption", func(t *testing.T) {
+		input := make([]int, 1000)
+		for i := range input {
+			input[i] = i
+		}
+		branches := TeeBounded(FromSlice(input), 3, 4)
+
+		var wg sync.WaitGroup
+		results := make([][]int, len(branches))
+		for i, b := range branches {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = b.Collect()
+			}()
+		}
+		wg.Wait()
+
+		for i, got := range results {
+			if !slices.Equal(got, input) {
+				t.Errorf("branch %d: got %d items, want %d in order", i, len(got), len(input))
+			}
+		}
+	})
+
+	t.Run("Early Stop Detaches Branch", func(t *testing.T) {
+		stopped := false
+		src := New(func(yield func(int) bool) {
+			defer func() { stopped = true }()
+			for i := range 10 {
+				if !yield(i) {
+					return
+				}
+			}
+		}, nil)
+
+		branches := TeeBounded(src, 2, 1)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		var first int
+		var rest []int
+		go func() {
+			defer wg.Done()
+			first, _ = branches[0].First()
+		}()
+		go func() {
+			defer wg.Done()
+			rest, _ = branches[1].Collect()
+		}()
+		wg.Wait()
+
+		// the stopped branch must not hold the other back
+		if first != 0 || len(rest) != 10 {
+			t.Errorf("expected first 0 and 10 items, got %d and %v", first, rest)
+		}
+		if !stopped {
+			t.Error("expected source to finish")
+		}
+	})
+
+	t.Run("Early Stop On All Branches Stops Source", func(t *testing.T) {
+		stopped := false
+		src := New(func(yield func(int) bool) {
+			defer func() { stopped = true }()
+			for i := 0; ; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}, nil)
+
+		branches := Tee(src, 2)
+		branches[0].Take(3).Collect()
+		branches[1].First()
+		if !stopped {
+			t.Error("expected source to be stopped once every branch detached")
+		}
+	})
+
+	t.Run("Unrun Branch Stops Source Once Collected", func(t *testing.T) {
+		var stopped atomic.Bool
+		src := New(func(yield func(int) bool) {
+			defer stopped.Store(true)
+			for i := 0; ; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}, nil)
+
+		func() {
+			branches := Tee(src, 2)
+			branches[0].First() // branches[1] is never run
+		}()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for !stopped.Load() && time.Now().Before(deadline) {
+			runtime.GC()
+			time.Sleep(10 * time.Millisecond)
+		}
+		if !stopped.Load() {
+			t.Error("expected source to be stopped once the branches were collected")
+		}
+	})
+
+	t.Run("Source Error Reaches Every Branch", func(t *testing.T) {
+		var errSource error
+		sentinel := errors.New("disk error")
+		src := New(func(yield func(int) bool) {
+			if !yield(1) {
+				return
+			}
+			errSource = sentinel
+		}, &errSource)
+
+		for i, b := range Tee(src, 2) {
+			if _, err := b.Collect(); !errors.Is(err, sentinel) {
+				t.Errorf("branch %d: expected %v, got %v", i, sentinel, err)
+			}
+		}
+	})
+
+	t.Run("Source Panic Fails Other Branches", func(t *testing.T) {
+		src := FromSeq(func(yield func(int) bool) {
+			for i := range 3 {
+				if !yield(i) {
+					return
+				}
+			}
+			panic("read failure")
+		})
+		branches := Tee(src, 2)
+
+		func() {
+			defer func() {
+				if r := recover(); r != "read failure" {
+					t.Errorf("expected the source panic, got %v", r)
+				}
+			}()
+			branches[0].Collect()
+			t.Error("expected the pulling branch to panic")
+		}()
+
+		if got, err := branches[1].Collect(); !errors.Is(err, errSourcePanic) {
+			t.Errorf("expected errSourcePanic, got %v (err: %v)", got, err)
+		}
+	})
+
+	t.Run("Branch Error Stops Siblings", func(t *testing.T) {
+		sentinel := errors.New("bad item")
+		branches := Tee(FromSlice([]int{1, 2, 3, 4}), 2)
+
+		failing := MapErr(branches[0], func(v int) (int, error) {
+			if v == 2 {
+				return 0, sentinel
+			}
+			return v, nil
+		})
+		if _, err := failing.Collect(); !errors.Is(err, sentinel) {
+			t.Fatalf("expected %v, got %v", sentinel, err)
+		}
+		if _, err := branches[1].Collect(); !errors.Is(err, sentinel) {
+			t.Errorf("expected sibling to report %v, got %v", sentinel, err)
+		}
+	})
+}
+
+func TestPartition(t *testing.T) {
+	t.Run("Sequential", func(t *testing.T) {
+		evens, odds := Partition(FromSlice([]int{1, 2, 3, 4, 5}), func(n int) bool {
+			return n%2 == 0
+		})
+
+		gotOdds, _ := odds.Collect()
+		gotEvens, _ := evens.Collect()
+		if !slices.Equal(gotEvens, []int{2, 4}) || !slices.Equal(gotOdds, []int{1, 3, 5}) {
+			t.Errorf("got evens %v, odds %v", gotEvens, gotOdds)
+		}
+	})
+
+	t.Run("Bounded Concurrent", func(t *testing.T) {
+		input := make([]int, 500)
+		for i := range input {
+			input[i] = i
+		}
+		small, large := PartitionBounded(FromSlice(input), func(n int) bool {
+			return n < 100
+		}, 2)
+
+		var wg sync.WaitGroup
+		var gotSmall, gotLarge []int
+		wg.Add(2)
+		go func() { defer wg.Done(); gotSmall, _ = small.Collect() }()
+		go func() { defer wg.Done(); gotLarge, _ = large.Collect() }()
+		wg.Wait()
+
+		if !slices.Equal(gotSmall, input[:100]) || !slices.Equal(gotLarge, input[100:]) {
+			t.Errorf("got %d small and %d large items", len(gotSmall), len(gotLarge))
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package stream
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+)
 
 // Last consumes the stream and returns the very last element.
 // Returns an error if the stream is empty or if an upstream error occurs.
@@ -41,3 +44,49 @@ func GroupBy[T any, K comparable](s Stream[T], keyFn func(T) K) (map[K][]T, erro
 	})
 	return res, err
 }
+
+// Aggregate folds each group of elements sharing a key into a single accumulator,
+// keeping only one accumulator per key instead of buffering every element.
+// Each group starts from a fresh init(), so accumulators such as maps or
+// slices are never shared between groups. The result yields groups in
+// first-seen key order once the whole stream has been consumed.
+// Use sequencedmap.Aggregate to get the result as an ordered map instead.
+func Aggregate[T any, K comparable, A any](s Stream[T], keyFn func(T) K, init func() A, fold func(A, T) A) Stream2[K, A] {
+	return Stream2[K, A]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq2[K, A] {
+			seq := s.run(errp)
+			return func(yield func(K, A) bool) {
+				index := make(map[K]int)
+				var keys []K
+				var accs []A
+
+				for v := range seq {
+					if *errp != nil {
+						return
+					}
+					k := keyFn(v)
+					i, ok := index[k]
+					if !ok {
+						i = len(keys)
+						index[k] = i
+						keys = append(keys, k)
+						accs = append(accs, init())
+					}
+					accs[i] = fold(accs[i], v)
+				}
+
+				// don't emit partial groups from a failed stream
+				if *errp != nil {
+					return
+				}
+				for i, k := range keys {
+					if !yield(k, accs[i]) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
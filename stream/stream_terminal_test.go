@@ -2,6 +2,7 @@ package stream
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 )
 
@@ -73,3 +74,34 @@ func TestTerminalExtensions(t *testing.T) {
 		}
 	})
 }
+
+func TestAggregate(t *testing.T) {
+	t.Run("Count per key in first-seen order", func(t *testing.T) {
+		words := []string{"pear", "fig", "plum", "kiwi", "fig"}
+		pairs, err := Aggregate(FromSlice(words), func(w string) byte { return w[0] }, func() int { return 0 }, func(n int, _ string) int {
+			return n + 1
+		}).Collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []Pair[byte, int]{{'p', 2}, {'f', 2}, {'k', 1}}
+		if !slices.Equal(pairs, want) {
+			t.Errorf("expected %v, got %v", want, pairs)
+		}
+	})
+
+	t.Run("Failed stream emits no groups", func(t *testing.T) {
+		var errSource = fmt.Errorf("disk error")
+		s := New(func(yield func(int) bool) {
+			yield(1)
+		}, &errSource)
+
+		emitted := 0
+		err := Aggregate(s, func(n int) int { return n }, func() int { return 0 }, func(a, b int) int { return a + b }).
+			ForEach(func(int, int) { emitted++ })
+		if err != errSource || emitted != 0 {
+			t.Errorf("expected %v and no groups, got %v and %d", errSource, err, emitted)
+		}
+	})
+}
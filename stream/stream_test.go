@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"testing"
 )
 
@@ -116,13 +117,13 @@ func TestShortCircuiting(t *testing.T) {
 
 		// Wrap the slice to count iterations
 		tracked := New(func(yield func(int) bool) {
-			for v := range s.seq {
+			for v := range s.Seq() {
 				iterations++
 				if !yield(v) {
 					return
 				}
 			}
-		}, s.err)
+		}, nil)
 
 		allOnes, _ := tracked.All(func(n int) bool { return n == 1 })
 
@@ -301,3 +302,136 @@ func TestStream_Reduce(t *testing.T) {
 		}
 	})
 }
+
+func TestStream_WhileOperators(t *testing.T) {
+	t.Run("TakeWhile stops at first failure", func(t *testing.T) {
+		got, err := FromSlice([]int{1, 2, 3, 10, 4, 5}).
+			TakeWhile(func(n int) bool { return n < 5 }).
+			Collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("DropWhile yields everything after first failure", func(t *testing.T) {
+		got, err := FromSlice([]int{1, 2, 10, 3, 20}).
+			DropWhile(func(n int) bool { return n < 5 }).
+			Collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []int{10, 3, 20}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("TakeWhile respects the live wire", func(t *testing.T) {
+		var errSource error
+		s := New(func(yield func(int) bool) {
+			if !yield(1) {
+				return
+			}
+			errSource = errors.New("boom")
+			yield(2)
+		}, &errSource)
+
+		seen := 0
+		err := s.TakeWhile(func(int) bool { return true }).ForEach(func(int) { seen++ })
+		if err == nil || seen != 1 {
+			t.Errorf("expected error after 1 item, got %v after %d", err, seen)
+		}
+	})
+}
+
+func TestStream_Peek(t *testing.T) {
+	var peeked []int
+	s := FromSlice([]int{1, 2, 3, 4}).
+		Peek(func(n int) { peeked = append(peeked, n) })
+
+	got, err := s.First()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Peek only sees what First pulls
+	if got != 1 || !slices.Equal(peeked, []int{1}) {
+		t.Errorf("got %v, peeked %v", got, peeked)
+	}
+}
+
+func TestStream_Enumerate(t *testing.T) {
+	pairs, err := FromSlice([]string{"a", "b", "c"}).Enumerate().Skip(1).Collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Pair[int, string]{{1, "b"}, {2, "c"}}
+	if !slices.Equal(pairs, want) {
+		t.Errorf("got %v, want %v", pairs, want)
+	}
+}
+
+func TestStream_Rerun(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	s := MapErr(FromSlice([]int{1, 2, 3}), func(n int) (int, error) {
+		calls++
+		if calls == 2 {
+			return 0, boom
+		}
+		return n, nil
+	})
+
+	t.Run("error does not leak into the next run", func(t *testing.T) {
+		if _, err := s.Count(); !errors.Is(err, boom) {
+			t.Fatalf("first run: expected boom, got %v", err)
+		}
+		got, err := s.Collect()
+		if err != nil {
+			t.Fatalf("second run: unexpected error: %v", err)
+		}
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("second run: got %v", got)
+		}
+	})
+
+	t.Run("caller-owned pointer is shared across runs", func(t *testing.T) {
+		var errPtr error
+		shared := MapErr(New(slices.Values([]int{1, 2}), &errPtr), func(n int) (int, error) {
+			if n == 2 {
+				return 0, boom
+			}
+			return n, nil
+		})
+		if _, err := shared.Collect(); !errors.Is(err, boom) {
+			t.Fatalf("expected boom, got %v", err)
+		}
+		if !errors.Is(errPtr, boom) {
+			t.Errorf("expected the caller's pointer to hold boom, got %v", errPtr)
+		}
+		// the tripped wire stays tripped, as before
+		if _, err := shared.Count(); !errors.Is(err, boom) {
+			t.Errorf("expected boom on rerun, got %v", err)
+		}
+	})
+
+	t.Run("concurrent runs", func(t *testing.T) {
+		evens := FromSlice([]int{1, 2, 3, 4, 5, 6}).
+			Filter(func(n int) bool { return n%2 == 0 })
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got, err := evens.Collect()
+				if err != nil || !slices.Equal(got, []int{2, 4, 6}) {
+					t.Errorf("got %v, %v", got, err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,282 @@
+package stream
+
+import (
+	"errors"
+	"iter"
+	"slices"
+)
+
+// ErrNotPresorted is reported by a Presorted window when the input turns out
+// not to be grouped by partition, or not ordered within one.
+var ErrNotPresorted = errors.New("stream: input is not ordered by partition")
+
+// WindowSpec describes SQL-style window functions, the equivalent of
+//
+//	OVER (PARTITION BY key ORDER BY order)
+//
+// Build one with Window, choose the columns to compute, then Apply it:
+//
+//	rows := stream.Window(func(o Order) string { return o.Customer }).
+//		OrderBy(func(a, b Order) int { return a.Date.Compare(b.Date) }).
+//		Lag(1).
+//		RunningSum(func(o Order) float64 { return o.Amount }).
+//		Apply(orders)
+//
+// RowNumber, Rank and DenseRank are always computed.
+// Like Pipeline, every method returns a new spec.
+type WindowSpec[T any, K comparable] struct {
+	key       func(T) K
+	order     func(a, b T) int
+	presorted bool
+	lag, lead int
+	sum       func(T) float64
+	avg       func(T) float64
+	avgN      int
+}
+
+// WindowRow is an input element together with its computed columns.
+// Columns that were not requested keep their zero value.
+type WindowRow[T any, K comparable] struct {
+	Value T
+	Key   K
+
+	RowNumber int // 1-based position in the partition
+	Rank      int // 1 + number of rows ordered strictly before this one; peers share a rank
+	DenseRank int // like Rank, without gaps after peers
+
+	Lag     T // the row Lag(n) rows back, if HasLag
+	HasLag  bool
+	Lead    T // the row Lead(n) rows ahead, if HasLead
+	HasLead bool
+
+	RunningSum float64 // sum of the RunningSum values so far, this row included
+	MovingAvg  float64 // mean of the MovingAverage values over the last n rows, this row included
+}
+
+// Window starts a spec whose rows are partitioned by key.
+func Window[T any, K comparable](key func(T) K) WindowSpec[T, K] {
+	return WindowSpec[T, K]{key: key}
+}
+
+// OrderBy orders the rows within each partition. Rows that compare equal are
+// peers and share a rank. Without OrderBy, rows keep their input order and,
+// as in SQL, are all peers.
+func (w WindowSpec[T, K]) OrderBy(cmp func(a, b T) int) WindowSpec[T, K] {
+	w.order = cmp
+	return w
+}
+
+// Presorted declares that the input is already grouped by partition and
+// ordered within each one, so rows are computed and emitted as they stream
+// by instead of being buffered. If the input breaks that promise, the stream
+// fails with ErrNotPresorted.
+func (w WindowSpec[T, K]) Presorted() WindowSpec[T, K] {
+	w.presorted = true
+	return w
+}
+
+// Lag fills Lag with the row n rows before the current one in its partition.
+func (w WindowSpec[T, K]) Lag(n int) WindowSpec[T, K] {
+	w.lag = max(n, 0)
+	return w
+}
+
+// Lead fills Lead with the row n rows after the current one in its partition.
+// Each row is held back until the row n ahead of it has arrived.
+func (w WindowSpec[T, K]) Lead(n int) WindowSpec[T, K] {
+	w.lead = max(n, 0)
+	return w
+}
+
+// RunningSum fills RunningSum with the cumulative sum of value over the partition.
+func (w WindowSpec[T, K]) RunningSum(value func(T) float64) WindowSpec[T, K] {
+	w.sum = value
+	return w
+}
+
+// MovingAverage fills MovingAvg with the mean of value over the last n rows
+// of the partition, or fewer at the start of it.
+func (w WindowSpec[T, K]) MovingAverage(n int, value func(T) float64) WindowSpec[T, K] {
+	w.avg = value
+	w.avgN = max(n, 1)
+	return w
+}
+
+// Apply computes the window over s.
+//
+// By default the whole input is buffered, then partitions are emitted in
+// first-seen key order, each sorted by OrderBy (stably). With Presorted, rows
+// are emitted in input order while streaming. Either way, nothing more is
+// emitted once the stream fails.
+func (w WindowSpec[T, K]) Apply(s Stream[T]) Stream[WindowRow[T, K]] {
+	return Stream[WindowRow[T, K]]{
+		err:     s.err,
+		metrics: s.metrics,
+		run: func(errp *error) iter.Seq[WindowRow[T, K]] {
+			seq := s.run(errp)
+			if w.presorted {
+				return w.stream(seq, errp)
+			}
+			return w.buffered(seq, errp)
+		},
+	}
+}
+
+func (w WindowSpec[T, K]) stream(seq iter.Seq[T], errp *error) iter.Seq[WindowRow[T, K]] {
+	return func(yield func(WindowRow[T, K]) bool) {
+		var p *partition[T, K]
+		done := make(map[K]struct{})
+		var prev T
+
+		for v := range seq {
+			if *errp != nil {
+				return
+			}
+			k := w.key(v)
+			if p == nil || k != p.key {
+				if _, ok := done[k]; ok {
+					*errp = ErrNotPresorted
+					return
+				}
+				if p != nil {
+					done[p.key] = struct{}{}
+					if !p.flush(yield) {
+						return
+					}
+				}
+				p = w.newPartition(k)
+			} else if w.order != nil && w.order(prev, v) > 0 {
+				*errp = ErrNotPresorted
+				return
+			}
+			prev = v
+			if !p.push(v, yield) {
+				return
+			}
+		}
+
+		if *errp == nil && p != nil {
+			p.flush(yield)
+		}
+	}
+}
+
+func (w WindowSpec[T, K]) buffered(seq iter.Seq[T], errp *error) iter.Seq[WindowRow[T, K]] {
+	return func(yield func(WindowRow[T, K]) bool) {
+		index := make(map[K]int)
+		var keys []K
+		var groups [][]T
+
+		for v := range seq {
+			if *errp != nil {
+				return
+			}
+			k := w.key(v)
+			i, ok := index[k]
+			if !ok {
+				i = len(keys)
+				index[k] = i
+				keys = append(keys, k)
+				groups = append(groups, nil)
+			}
+			groups[i] = append(groups[i], v)
+		}
+		if *errp != nil {
+			return
+		}
+
+		for i, k := range keys {
+			if w.order != nil {
+				slices.SortStableFunc(groups[i], w.order)
+			}
+			p := w.newPartition(k)
+			for _, v := range groups[i] {
+				if !p.push(v, yield) {
+					return
+				}
+			}
+			if !p.flush(yield) {
+				return
+			}
+			groups[i] = nil // release the partition once emitted
+		}
+	}
+}
+
+// partition computes the columns of one partition's rows, fed in order.
+type partition[T any, K comparable] struct {
+	spec WindowSpec[T, K]
+	key  K
+
+	n, rank, dense int
+	prev           T
+
+	history []T       // the last spec.lag rows, for Lag
+	window  []float64 // the last spec.avgN values, for MovingAvg
+	winSum  float64
+	sum     float64
+
+	pending []WindowRow[T, K] // rows waiting for their Lead
+}
+
+func (w WindowSpec[T, K]) newPartition(k K) *partition[T, K] {
+	return &partition[T, K]{spec: w, key: k}
+}
+
+// push computes v's row and emits every row whose Lead is now known.
+func (p *partition[T, K]) push(v T, yield func(WindowRow[T, K]) bool) bool {
+	w := p.spec
+	p.n++
+	// without an order every row is a peer of the first
+	if p.n == 1 || (w.order != nil && w.order(p.prev, v) != 0) {
+		p.rank = p.n
+		p.dense++
+	}
+	p.prev = v
+
+	row := WindowRow[T, K]{Value: v, Key: p.key, RowNumber: p.n, Rank: p.rank, DenseRank: p.dense}
+
+	if w.lag > 0 {
+		if len(p.history) == w.lag {
+			row.Lag, row.HasLag = p.history[0], true
+			p.history = append(p.history[:0], p.history[1:]...)
+		}
+		p.history = append(p.history, v)
+	}
+	if w.sum != nil {
+		p.sum += w.sum(v)
+		row.RunningSum = p.sum
+	}
+	if w.avg != nil {
+		x := w.avg(v)
+		if len(p.window) == w.avgN {
+			p.winSum -= p.window[0]
+			p.window = append(p.window[:0], p.window[1:]...)
+		}
+		p.window = append(p.window, x)
+		p.winSum += x
+		row.MovingAvg = p.winSum / float64(len(p.window))
+	}
+
+	p.pending = append(p.pending, row)
+	if len(p.pending) <= w.lead {
+		return true
+	}
+	out := p.pending[0]
+	if w.lead > 0 {
+		out.Lead, out.HasLead = p.pending[w.lead].Value, true
+	}
+	p.pending = append(p.pending[:0], p.pending[1:]...)
+	return yield(out)
+}
+
+// flush emits the rows still waiting at the end of the partition, which have no Lead.
+func (p *partition[T, K]) flush(yield func(WindowRow[T, K]) bool) bool {
+	for _, row := range p.pending {
+		if !yield(row) {
+			return false
+		}
+	}
+	p.pending = nil
+	return true
+}
@@ -0,0 +1,153 @@
+package stream
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+)
+
+type sale struct {
+	customer string
+	day      int
+	amount   float64
+}
+
+var sales = []sale{
+	{"bob", 2, 20},
+	{"ann", 1, 10},
+	{"bob", 1, 5},
+	{"ann", 2, 30},
+	{"ann", 2, 40},
+	{"ann", 3, 50},
+}
+
+func byCustomer(s sale) string { return s.customer }
+func byDay(a, b sale) int      { return cmp.Compare(a.day, b.day) }
+func amount(s sale) float64    { return s.amount }
+
+func TestWindow(t *testing.T) {
+	t.Run("Buffered Ranks And Running Sum", func(t *testing.T) {
+		rows, err := Window(byCustomer).OrderBy(byDay).RunningSum(amount).Apply(FromSlice(sales)).Collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		type cols struct {
+			customer         string
+			day              int
+			row, rank, dense int
+			sum              float64
+		}
+		var got []cols
+		for _, r := range rows {
+			got = append(got, cols{r.Key, r.Value.day, r.RowNumber, r.Rank, r.DenseRank, r.RunningSum})
+		}
+		// partitions in first-seen order (bob first), sorted by day within each
+		want := []cols{
+			{"bob", 1, 1, 1, 1, 5},
+			{"bob", 2, 2, 2, 2, 25},
+			{"ann", 1, 1, 1, 1, 10},
+			{"ann", 2, 2, 2, 2, 40},
+			{"ann", 2, 3, 2, 2, 80},
+			{"ann", 3, 4, 4, 3, 130},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got  %v\nwant %v", got, want)
+		}
+	})
+
+	t.Run("Lag And Lead", func(t *testing.T) {
+		rows, err := Window(func(n int) int { return n / 10 }).Lag(1).Lead(2).
+			Apply(FromSlice([]int{1, 2, 3, 11, 12})).Collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		type lagLead struct {
+			v, lag, lead    int
+			hasLag, hasLead bool
+		}
+		var got []lagLead
+		for _, r := range rows {
+			got = append(got, lagLead{r.Value, r.Lag, r.Lead, r.HasLag, r.HasLead})
+		}
+		want := []lagLead{
+			{1, 0, 3, false, true},
+			{2, 1, 0, true, false},
+			{3, 2, 0, true, false},
+			{11, 0, 0, false, false},
+			{12, 11, 0, true, false},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got  %v\nwant %v", got, want)
+		}
+	})
+
+	t.Run("Moving Average", func(t *testing.T) {
+		rows, _ := Window(func(int) string { return "all" }).
+			MovingAverage(2, func(n int) float64 { return float64(n) }).
+			Apply(FromSlice([]int{2, 4, 6, 8})).Collect()
+		var got []float64
+		for _, r := range rows {
+			got = append(got, r.MovingAvg)
+		}
+		if !slices.Equal(got, []float64{2, 3, 5, 7}) {
+			t.Errorf("expected [2 3 5 7], got %v", got)
+		}
+	})
+
+	t.Run("No Order Means Peers", func(t *testing.T) {
+		rows, _ := Window(func(int) int { return 0 }).Apply(FromSlice([]int{3, 1, 2})).Collect()
+		for i, r := range rows {
+			if r.RowNumber != i+1 || r.Rank != 1 || r.DenseRank != 1 {
+				t.Errorf("row %d: unexpected %+v", i, r)
+			}
+		}
+	})
+
+	t.Run("Presorted Streams", func(t *testing.T) {
+		pulled := 0
+		src := FromSlice([]sale{{"ann", 1, 10}, {"ann", 2, 30}, {"bob", 1, 5}, {"bob", 2, 20}}).
+			Peek(func(sale) { pulled++ })
+
+		first, err := Window(byCustomer).OrderBy(byDay).Presorted().RunningSum(amount).Apply(src).First()
+		if err != nil || first.Value.day != 1 || first.RunningSum != 10 {
+			t.Errorf("unexpected first row %+v (err: %v)", first, err)
+		}
+		if pulled != 1 {
+			t.Errorf("expected a single row to be pulled, got %d", pulled)
+		}
+
+		rows, err := Window(byCustomer).Presorted().RunningSum(amount).Apply(src).Collect()
+		if err != nil || len(rows) != 4 || rows[3].RunningSum != 25 {
+			t.Errorf("unexpected rows %+v (err: %v)", rows, err)
+		}
+	})
+
+	t.Run("Presorted Violations", func(t *testing.T) {
+		w := Window(byCustomer).OrderBy(byDay).Presorted()
+
+		if _, err := w.Apply(FromSlice(sales)).Collect(); !errors.Is(err, ErrNotPresorted) {
+			t.Errorf("expected ErrNotPresorted for interleaved partitions, got %v", err)
+		}
+		unordered := []sale{{"ann", 2, 0}, {"ann", 1, 0}}
+		if _, err := w.Apply(FromSlice(unordered)).Collect(); !errors.Is(err, ErrNotPresorted) {
+			t.Errorf("expected ErrNotPresorted for unordered rows, got %v", err)
+		}
+	})
+
+	t.Run("Upstream Error", func(t *testing.T) {
+		boom := errors.New("boom")
+		s := MapErr(FromSlice(sales), func(s sale) (sale, error) {
+			if s.day == 3 {
+				return s, boom
+			}
+			return s, nil
+		})
+		n := 0
+		err := Window(byCustomer).Apply(s).ForEach(func(WindowRow[sale, string]) { n++ })
+		if !errors.Is(err, boom) || n != 0 {
+			t.Errorf("expected boom and no rows, got %d rows (err: %v)", n, err)
+		}
+	})
+}